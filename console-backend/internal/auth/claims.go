@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"portal-backend/internal/config"
+)
+
+// userInfoCacheTTL userinfo 보강 결과의 최대 캐시 시간 (토큰 만료가 더 빠르면 만료 시점까지)
+const userInfoCacheTTL = 5 * time.Minute
+
+// userGroupsFromClaims 검증된 클레임에서 사용자 정보와 그룹 추출
+// 그룹은 OIDC_GROUPS_CLAIM 경로에서 읽음 (기본값: groups)
+func userGroupsFromClaims(claims map[string]any) *UserGroups {
+	userGroups := &UserGroups{}
+
+	// 사용자 ID 추출 (sub 클레임)
+	if sub, ok := claims["sub"].(string); ok {
+		userGroups.UserID = sub
+	}
+
+	// 사용자명 추출 (preferred_username 클레임)
+	if username, ok := claims["preferred_username"].(string); ok {
+		userGroups.Username = username
+	}
+
+	// 이메일 추출 (email 클레임)
+	if email, ok := claims["email"].(string); ok {
+		userGroups.Email = email
+	}
+
+	if value, ok := lookupClaim(claims, config.Get().OIDC.GroupsClaim); ok {
+		userGroups.Groups = parseGroups(value)
+	}
+
+	return userGroups
+}
+
+// mergeUserInfoClaims userinfo 클레임으로 비어 있는 프로필 값을 채우고 그룹은 합집합으로 병합
+// userinfo에 sub가 없거나 토큰과 다르면 같은 사용자의 응답임을 확인할 수 없으므로 병합하지 않음
+func mergeUserInfoClaims(userGroups *UserGroups, userInfoClaims map[string]any) error {
+	enriched := userGroupsFromClaims(userInfoClaims)
+
+	if enriched.UserID == "" {
+		return fmt.Errorf("userinfo response does not contain a subject")
+	}
+	if enriched.UserID != userGroups.UserID {
+		return fmt.Errorf("userinfo subject %q does not match token subject %q", enriched.UserID, userGroups.UserID)
+	}
+
+	if userGroups.Username == "" {
+		userGroups.Username = enriched.Username
+	}
+	if userGroups.Email == "" {
+		userGroups.Email = enriched.Email
+	}
+	for _, group := range enriched.Groups {
+		if !slices.Contains(userGroups.Groups, group) {
+			userGroups.Groups = append(userGroups.Groups, group)
+		}
+	}
+	return nil
+}
+
+// lookupClaim 클레임 경로 조회
+// 경로 전체가 최상위 키로 존재하면(예: https://example.com/groups) 그대로 사용하고,
+// 아니면 점(.)으로 나누어 중첩 객체를 따라감 (예: realm_access.roles)
+func lookupClaim(claims map[string]any, path string) (any, bool) {
+	if path == "" {
+		return nil, false
+	}
+	if value, ok := claims[path]; ok {
+		return value, true
+	}
+
+	var current any = claims
+	for _, segment := range strings.Split(path, ".") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = object[segment]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// parseGroups 그룹 클레임 값을 문자열 목록으로 정규화
+func parseGroups(value any) []string {
+	groups := make([]string, 0)
+
+	switch v := value.(type) {
+	case []any:
+		// JSON 배열 형태
+		for _, group := range v {
+			if groupStr, ok := group.(string); ok && groupStr != "" {
+				groups = append(groups, groupStr)
+			}
+		}
+	case []string:
+		// 문자열 배열 형태
+		groups = append(groups, v...)
+	case string:
+		// 단일 문자열 또는 쉼표로 구분된 문자열
+		for _, group := range strings.Split(v, ",") {
+			if group = strings.TrimSpace(group); group != "" {
+				groups = append(groups, group)
+			}
+		}
+	}
+
+	return groups
+}
+
+// userInfoCacheEntry 토큰별 userinfo 클레임 캐시 항목
+type userInfoCacheEntry struct {
+	claims    map[string]any
+	expiresAt time.Time
+}
+
+// userInfoCache 요청마다 userinfo를 호출하지 않도록 토큰 해시별로 보강 결과를 캐시
+type userInfoCache struct {
+	mu      sync.Mutex
+	entries map[string]userInfoCacheEntry
+}
+
+func newUserInfoCache() *userInfoCache {
+	return &userInfoCache{entries: make(map[string]userInfoCacheEntry)}
+}
+
+// claims 캐시된 userinfo 클레임을 반환하고, 없으면 fetch로 가져와 tokenExpiry와 TTL 중 빠른 시점까지 저장
+func (uc *userInfoCache) claims(rawAccessToken string, tokenExpiry time.Time, fetch func() (map[string]any, error)) (map[string]any, error) {
+	sum := sha256.Sum256([]byte(rawAccessToken))
+	key := hex.EncodeToString(sum[:])
+	now := time.Now()
+
+	uc.mu.Lock()
+	entry, exists := uc.entries[key]
+	uc.mu.Unlock()
+	if exists && now.Before(entry.expiresAt) {
+		return entry.claims, nil
+	}
+
+	claims, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := now.Add(userInfoCacheTTL)
+	if !tokenExpiry.IsZero() && tokenExpiry.Before(expiresAt) {
+		expiresAt = tokenExpiry
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	for cachedKey, cached := range uc.entries {
+		if now.After(cached.expiresAt) {
+			delete(uc.entries, cachedKey)
+		}
+	}
+	uc.entries[key] = userInfoCacheEntry{claims: claims, expiresAt: expiresAt}
+
+	return claims, nil
+}
+
+// enrichFromUserInfo userinfo 클레임으로 사용자 정보를 보강 (실패해도 토큰 클레임만으로 계속 진행)
+func (p *OIDCProvider) enrichFromUserInfo(ctx context.Context, userGroups *UserGroups, rawAccessToken string, tokenExpiry time.Time) error {
+	userInfoClaims, err := p.userInfoCache.claims(rawAccessToken, tokenExpiry, func() (map[string]any, error) {
+		userInfo, err := p.FetchUserInfo(ctx, rawAccessToken)
+		if err != nil {
+			return nil, err
+		}
+		claims := make(map[string]any)
+		if err := userInfo.Claims(&claims); err != nil {
+			return nil, err
+		}
+		return claims, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return mergeUserInfoClaims(userGroups, userInfoClaims)
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookupClaim(t *testing.T) {
+	claims := map[string]any{
+		"groups":                     []any{"/org/blue/adm"},
+		"https://example.com/groups": []any{"dotted"},
+		"realm_access":               map[string]any{"roles": []any{"admin", "user"}},
+		"resource_access":            map[string]any{"portal": map[string]any{"roles": []any{"viewer"}}},
+		"realm_access.roles":         "top-level wins",
+		"scalar":                     "value",
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		want   any
+		wantOK bool
+	}{
+		{name: "top-level key", path: "groups", want: []any{"/org/blue/adm"}, wantOK: true},
+		{name: "dotted top-level key", path: "https://example.com/groups", want: []any{"dotted"}, wantOK: true},
+		{name: "top-level key preferred over nested path", path: "realm_access.roles", want: "top-level wins", wantOK: true},
+		{name: "nested path", path: "resource_access.portal.roles", want: []any{"viewer"}, wantOK: true},
+		{name: "missing nested segment", path: "resource_access.other.roles", wantOK: false},
+		{name: "path through non-object", path: "scalar.roles", wantOK: false},
+		{name: "missing key", path: "roles", wantOK: false},
+		{name: "empty path", path: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := lookupClaim(claims, tt.path)
+			if ok != tt.wantOK {
+				t.Fatalf("lookupClaim(%q) ok = %v, want %v", tt.path, ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lookupClaim(%q) = %#v, want %#v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGroups(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  []string
+	}{
+		{name: "json array", value: []any{"/org/blue/adm", "/org/red/dev"}, want: []string{"/org/blue/adm", "/org/red/dev"}},
+		{name: "json array skips non-strings and empty", value: []any{"a", 1, "", nil, "b"}, want: []string{"a", "b"}},
+		{name: "string slice", value: []string{"a", "b"}, want: []string{"a", "b"}},
+		{name: "single string", value: "cluster-admins", want: []string{"cluster-admins"}},
+		{name: "comma separated string", value: "a, b ,,c", want: []string{"a", "b", "c"}},
+		{name: "empty string", value: "", want: []string{}},
+		{name: "unsupported type", value: map[string]any{"a": "b"}, want: []string{}},
+		{name: "nil", value: nil, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseGroups(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseGroups(%#v) = %#v, want %#v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeUserInfoClaims(t *testing.T) {
+	tests := []struct {
+		name     string
+		userInfo map[string]any
+		want     *UserGroups
+		wantErr  bool
+	}{
+		{
+			name:     "same subject fills profile and merges groups",
+			userInfo: map[string]any{"sub": "user-1", "email": "user@example.com", "groups": []any{"/org/blue/adm", "/org/red/dev"}},
+			want:     &UserGroups{UserID: "user-1", Username: "alice", Email: "user@example.com", Groups: []string{"/org/blue/adm", "/org/red/dev"}},
+		},
+		{
+			name:     "missing subject is rejected",
+			userInfo: map[string]any{"email": "user@example.com", "groups": []any{"cluster-admins"}},
+			want:     &UserGroups{UserID: "user-1", Username: "alice", Groups: []string{"/org/blue/adm"}},
+			wantErr:  true,
+		},
+		{
+			name:     "different subject is rejected",
+			userInfo: map[string]any{"sub": "user-2", "groups": []any{"cluster-admins"}},
+			want:     &UserGroups{UserID: "user-1", Username: "alice", Groups: []string{"/org/blue/adm"}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userGroups := &UserGroups{UserID: "user-1", Username: "alice", Groups: []string{"/org/blue/adm"}}
+
+			err := mergeUserInfoClaims(userGroups, tt.userInfo)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mergeUserInfoClaims() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(userGroups, tt.want) {
+				t.Errorf("mergeUserInfoClaims() = %#v, want %#v", userGroups, tt.want)
+			}
+		})
+	}
+}
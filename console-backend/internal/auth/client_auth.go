@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+
+	"portal-backend/internal/config"
+)
+
+// clientAssertionType private_key_jwt 클라이언트 인증의 assertion 종류 (RFC 7523)
+const clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// clientAssertionLifetime 클라이언트 assertion 유효 시간
+const clientAssertionLifetime = 5 * time.Minute
+
+var (
+	signingKeyOnce sync.Once
+	signingKey     any // *rsa.PrivateKey 또는 *ecdsa.PrivateKey
+	signingMethod  jwt.SigningMethod
+	signingKeyErr  error
+)
+
+// applyClientAuth 설정된 인증 방식으로 토큰 요청에 클라이언트 인증 추가
+// client_secret_basic이면 Authorization 헤더 값(base64)을 반환하고, 나머지는 폼 파라미터에 추가
+func applyClientAuth(tokenEndpoint string, data url.Values) (string, error) {
+	cfg := config.Get()
+
+	switch cfg.OIDC.ClientAuthMethod {
+	case config.ClientAuthPost:
+		data.Set("client_id", cfg.OIDC.ClientID)
+		data.Set("client_secret", cfg.OIDC.ClientSecret)
+		return "", nil
+
+	case config.ClientAuthPrivateKey:
+		assertion, err := newClientAssertion(tokenEndpoint)
+		if err != nil {
+			return "", err
+		}
+		data.Set("client_id", cfg.OIDC.ClientID)
+		data.Set("client_assertion_type", clientAssertionType)
+		data.Set("client_assertion", assertion)
+		return "", nil
+
+	default:
+		// RFC 6749 2.3.1: 클라이언트 ID와 시크릿은 폼 인코딩 후 Basic 인증에 사용
+		credentials := url.QueryEscape(cfg.OIDC.ClientID) + ":" + url.QueryEscape(cfg.OIDC.ClientSecret)
+		return base64.StdEncoding.EncodeToString([]byte(credentials)), nil
+	}
+}
+
+// clientAssertionOptions oauth2 코드 교환에 private_key_jwt assertion 파라미터 추가
+// 다른 인증 방식은 oauth2.Config의 AuthStyle이 처리하므로 옵션이 없음
+func clientAssertionOptions(tokenEndpoint string) ([]oauth2.AuthCodeOption, error) {
+	if config.Get().OIDC.ClientAuthMethod != config.ClientAuthPrivateKey {
+		return nil, nil
+	}
+
+	assertion, err := newClientAssertion(tokenEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	return []oauth2.AuthCodeOption{
+		oauth2.SetAuthURLParam("client_assertion_type", clientAssertionType),
+		oauth2.SetAuthURLParam("client_assertion", assertion),
+	}, nil
+}
+
+// newClientAssertion 토큰 엔드포인트를 audience로 하는 서명된 클라이언트 assertion 생성
+func newClientAssertion(tokenEndpoint string) (string, error) {
+	key, method, err := loadClientSigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	cfg := config.Get()
+	jti, err := GenerateRandomString(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(method, jwt.RegisteredClaims{
+		Issuer:    cfg.OIDC.ClientID,
+		Subject:   cfg.OIDC.ClientID,
+		Audience:  jwt.ClaimStrings{tokenEndpoint},
+		ID:        jti,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(clientAssertionLifetime)),
+	})
+	if cfg.OIDC.ClientKeyID != "" {
+		token.Header["kid"] = cfg.OIDC.ClientKeyID
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign client assertion: %v", err)
+	}
+	return signed, nil
+}
+
+// loadClientSigningKey OIDC_CLIENT_PRIVATE_KEY_FILE의 PEM 키를 한 번만 읽어 서명 방식과 함께 반환
+// RSA 키는 RS256, EC 키는 곡선에 맞는 ES256/ES384/ES512 사용
+func loadClientSigningKey() (any, jwt.SigningMethod, error) {
+	signingKeyOnce.Do(func() {
+		path := config.Get().OIDC.ClientPrivateKeyFile
+		pemData, err := os.ReadFile(path)
+		if err != nil {
+			signingKeyErr = fmt.Errorf("failed to read client private key: %v", err)
+			return
+		}
+
+		if rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemData); err == nil {
+			signingKey, signingMethod = rsaKey, jwt.SigningMethodRS256
+			return
+		}
+
+		ecKey, err := jwt.ParseECPrivateKeyFromPEM(pemData)
+		if err != nil {
+			signingKeyErr = fmt.Errorf("client private key %s is neither an RSA nor an EC key", path)
+			return
+		}
+		switch ecKey.Curve.Params().BitSize {
+		case 256:
+			signingMethod = jwt.SigningMethodES256
+		case 384:
+			signingMethod = jwt.SigningMethodES384
+		case 521:
+			signingMethod = jwt.SigningMethodES512
+		default:
+			signingKeyErr = fmt.Errorf("unsupported EC curve for client private key: %s", ecKey.Curve.Params().Name)
+			return
+		}
+		signingKey = ecKey
+	})
+
+	return signingKey, signingMethod, signingKeyErr
+}
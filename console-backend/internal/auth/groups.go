@@ -2,11 +2,9 @@ package auth
 
 import (
 	"encoding/json"
-	"fmt"
+	"maps"
 	"slices"
 	"strings"
-
-	"github.com/golang-jwt/jwt/v5"
 )
 
 // UserGroups 사용자 그룹 정보
@@ -17,107 +15,75 @@ type UserGroups struct {
 	Email    string   `json:"email,omitempty"`
 }
 
-// ExtractUserGroups ID 토큰에서 사용자 그룹 정보 추출
-func ExtractUserGroups(idToken string) (*UserGroups, error) {
-	token, _, err := new(jwt.Parser).ParseUnverified(idToken, jwt.MapClaims{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse token: %v", err)
-	}
-
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return nil, fmt.Errorf("invalid token claims")
-	}
-
-	userGroups := &UserGroups{}
+// NamespaceRoles 네임스페이스별 최고 역할 (예: {"blue": "admin", "red": "viewer"})
+type NamespaceRoles map[string]string
 
-	// 사용자 ID 추출 (sub 클레임)
-	if sub, ok := claims["sub"].(string); ok {
-		userGroups.UserID = sub
+// ToJSON 네임스페이스별 역할을 JSON 문자열로 변환
+func (nr NamespaceRoles) ToJSON() string {
+	data, err := json.Marshal(nr)
+	if err != nil {
+		return "{}"
 	}
+	return string(data)
+}
 
-	// 사용자명 추출 (preferred_username 클레임)
-	if username, ok := claims["preferred_username"].(string); ok {
-		userGroups.Username = username
-	}
+// RoleGrants 그룹 매핑 규칙(GROUP_MAPPINGS_FILE)을 적용한 네임스페이스별 역할
+func (ug *UserGroups) RoleGrants() RoleGrants {
+	return getRoleMapper().resolve(ug.Groups)
+}
 
-	// 이메일 추출 (email 클레임)
-	if email, ok := claims["email"].(string); ok {
-		userGroups.Email = email
+// GetUserRole 사용자의 최고 권한 역할 반환
+// 클러스터 관리자는 가장 높은 역할, 매핑되는 그룹이 없으면 user
+func (ug *UserGroups) GetUserRole() string {
+	mapper := getRoleMapper()
+	grants := mapper.resolve(ug.Groups)
+	if grants.ClusterAdmin {
+		return mapper.highestRole()
 	}
 
-	// 그룹 정보 추출 (groups 클레임)
-	if groupsInterface, ok := claims["groups"]; ok {
-		switch groups := groupsInterface.(type) {
-		case []interface{}:
-			// JSON 배열 형태
-			for _, group := range groups {
-				if groupStr, ok := group.(string); ok {
-					userGroups.Groups = append(userGroups.Groups, groupStr)
-				}
-			}
-		case []string:
-			// 문자열 배열 형태
-			userGroups.Groups = groups
-		case string:
-			// 단일 문자열 또는 쉼표로 구분된 문자열
-			if strings.Contains(groups, ",") {
-				userGroups.Groups = strings.Split(groups, ",")
-				// 공백 제거
-				for i, group := range userGroups.Groups {
-					userGroups.Groups[i] = strings.TrimSpace(group)
-				}
-			} else {
-				userGroups.Groups = []string{groups}
-			}
+	userRole := DefaultRole
+	for _, role := range grants.NamespaceRoles {
+		if mapper.priority(role) < mapper.priority(userRole) {
+			userRole = role
 		}
 	}
-
-	return userGroups, nil
+	return userRole
 }
 
-// GetUserRole 사용자의 최고 권한 역할 반환
-// 우선순위: admin > developer > viewer > user
-func (ug *UserGroups) GetUserRole() string {
-	for _, group := range ug.Groups {
-		// /최상위그룹/서비스명/권한 형태 파싱
-		if !strings.HasPrefix(group, "/") {
-			continue
-		}
+// NamespaceRoles 네임스페이스별 최고 역할 반환 (서비스마다 다른 역할을 가진 사용자도 정확히 표현)
+func (ug *UserGroups) NamespaceRoles() NamespaceRoles {
+	return ug.RoleGrants().NamespaceRoles
+}
 
-		parts := strings.Split(strings.TrimPrefix(group, "/"), "/")
-		if len(parts) < 3 {
-			continue
-		}
+// HasRole 네임스페이스에 특정 역할 이상의 권한이 있는지 확인
+// 클러스터 관리자는 모든 네임스페이스에 최고 역할을 가지며, 기본 사용자 역할(user)은 누구나 통과
+func (ug *UserGroups) HasRole(namespace, role string) bool {
+	mapper := getRoleMapper()
+	required := strings.ToLower(role)
+	if required == DefaultRole {
+		return true
+	}
+	if !slices.Contains(mapper.roles, required) {
+		return false
+	}
 
-		role := parts[2]
-		switch role {
-		case "adm":
-			return "admin"
-		case "dev":
-			return "developer"
-		case "view":
-			return "viewer"
-		}
+	grants := mapper.resolve(ug.Groups)
+	if grants.ClusterAdmin {
+		return true
 	}
 
-	return "user" // 기본 역할
+	granted, exists := grants.NamespaceRoles[namespace]
+	return exists && mapper.priority(granted) <= mapper.priority(required)
 }
 
-// HasRole 특정 역할 권한이 있는지 확인
-func (ug *UserGroups) HasRole(role string) bool {
-	userRole := ug.GetUserRole()
-
-	switch strings.ToLower(role) {
-	case "admin":
-		return userRole == "admin"
-	case "developer":
-		return userRole == "admin" || userRole == "developer"
-	case "viewer":
-		return userRole == "admin" || userRole == "developer" || userRole == "viewer"
-	default:
-		return true // 기본 사용자 권한
+// CanAccessNamespace 네임스페이스에 역할이 부여되었는지 확인 (클러스터 관리자는 모든 네임스페이스 허용)
+func (ug *UserGroups) CanAccessNamespace(namespace string) bool {
+	grants := ug.RoleGrants()
+	if grants.ClusterAdmin {
+		return true
 	}
+	_, exists := grants.NamespaceRoles[namespace]
+	return exists
 }
 
 // ToJSON 그룹 정보를 JSON 문자열로 변환
@@ -129,43 +95,47 @@ func (ug *UserGroups) ToJSON() string {
 	return string(data)
 }
 
-// RolePriority 역할 우선순위를 정의 (낮을수록 높음)
-var RolePriority = map[string]int{
-	"adm":  1, // 최고 권한
-	"dev":  2, // 중간 권한
-	"view": 3, // 최저 권한
+// Namespaces 사용자가 역할을 가진 네임스페이스 목록을 이름순으로 반환
+func (ug *UserGroups) Namespaces() []string {
+	namespaces := slices.Collect(maps.Keys(ug.RoleGrants().NamespaceRoles))
+	slices.Sort(namespaces)
+	return namespaces
+}
+
+// RoleSummary 콘솔 USER_ROLES 표시용 역할 문자열 (예: cluster-admin/blue-admin/red-developer)
+func (ug *UserGroups) RoleSummary() string {
+	grants := ug.RoleGrants()
+
+	roles := make([]string, 0, len(grants.NamespaceRoles)+1)
+	if grants.ClusterAdmin {
+		roles = append(roles, ClusterAdminRole)
+	}
+	for _, namespace := range ug.Namespaces() {
+		roles = append(roles, namespace+"-"+grants.NamespaceRoles[namespace])
+	}
+
+	if len(roles) == 0 {
+		return DefaultRole
+	}
+	return strings.Join(roles, "/")
 }
 
 // DetermineDefaultNamespace 사용자의 그룹 목록을 기반으로 기본 네임스페이스를 결정합니다.
 func (ug *UserGroups) DetermineDefaultNamespace() string {
-	// cluster-admins 그룹이 있으면 무조건 default 네임스페이스를 반환합니다.
-	if slices.Contains(ug.Groups, "cluster-admins") {
+	mapper := getRoleMapper()
+	grants := mapper.resolve(ug.Groups)
+
+	// 클러스터 관리자 규칙과 일치하면 무조건 default 네임스페이스를 반환합니다.
+	if grants.ClusterAdmin {
 		return "default"
 	}
 
-	highestPriority := 99
+	highestPriority := mapper.priority(DefaultRole)
 	candidateNamespaces := make([]string, 0)
 
-	// 다른 그룹들을 순회하며 가장 높은 우선순위의 네임스페이스 후보들을 수집합니다.
-	for _, group := range ug.Groups {
-		// /최상위그룹/서비스명/권한 형태 파싱
-		if !strings.HasPrefix(group, "/") {
-			continue
-		}
-
-		parts := strings.Split(strings.TrimPrefix(group, "/"), "/")
-		if len(parts) < 3 {
-			continue
-		}
-
-		namespace := parts[1] // 서비스명
-		role := parts[2]      // 권한 (adm, dev, view)
-
-		priority, ok := RolePriority[role]
-		if !ok {
-			continue
-		}
-
+	// 가장 높은 우선순위의 네임스페이스 후보들을 수집합니다.
+	for namespace, role := range grants.NamespaceRoles {
+		priority := mapper.priority(role)
 		if priority < highestPriority {
 			highestPriority = priority
 			candidateNamespaces = []string{namespace}
@@ -173,9 +143,7 @@ func (ug *UserGroups) DetermineDefaultNamespace() string {
 		}
 
 		if priority == highestPriority {
-			if !slices.Contains(candidateNamespaces, namespace) {
-				candidateNamespaces = append(candidateNamespaces, namespace)
-			}
+			candidateNamespaces = append(candidateNamespaces, namespace)
 		}
 	}
 
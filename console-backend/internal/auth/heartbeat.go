@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"portal-backend/internal/config"
+)
+
+// heartbeatKeyLabel JWT 서명 키에서 heartbeat 전용 키를 파생할 때 사용하는 라벨
+const heartbeatKeyLabel = "console-heartbeat"
+
+// heartbeatKey 활동 보고 토큰 서명 키
+// CONSOLE_HEARTBEAT_SECRET이 있으면 그대로 사용하고, 없으면 HMAC(JWT_SECRET_KEY, "console-heartbeat")로 파생하여
+// 인증 없이 호출되는 heartbeat 경로가 portal-jwt 쿠키 서명 키를 직접 사용하지 않도록 함
+func heartbeatKey() []byte {
+	cfg := config.Get()
+	if cfg.Console.HeartbeatSecret != "" {
+		return []byte(cfg.Console.HeartbeatSecret)
+	}
+	mac := hmac.New(sha256.New, []byte(cfg.JWT.SecretKey))
+	mac.Write([]byte(heartbeatKeyLabel))
+	return mac.Sum(nil)
+}
+
+// GenerateHeartbeatToken 콘솔 세션의 활동 보고용 토큰 생성 (세션 ID에 대한 HMAC)
+func GenerateHeartbeatToken(resourceID string) string {
+	mac := hmac.New(sha256.New, heartbeatKey())
+	mac.Write([]byte(resourceID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyHeartbeatToken 활동 보고용 토큰 검증
+func VerifyHeartbeatToken(resourceID, token string) bool {
+	expected := GenerateHeartbeatToken(resourceID)
+	return hmac.Equal([]byte(expected), []byte(token))
+}
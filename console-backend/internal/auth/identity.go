@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"portal-backend/internal/config"
+	"portal-backend/internal/logger"
+)
+
+// Identity 인증된 요청의 사용자 정보
+type Identity struct {
+	Subject  string   `json:"sub"`
+	Username string   `json:"username"`
+	Email    string   `json:"email,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	Groups   []string `json:"groups"`
+	Role     string   `json:"role"` // 전체 네임스페이스 중 최고 역할
+
+	NamespaceRoles NamespaceRoles `json:"namespace_roles"` // 네임스페이스별 최고 역할
+
+	Token string `json:"-"` // 원본 Bearer 액세스 토큰 (토큰 교환에 사용)
+}
+
+// UserID 리소스 라벨과 로그에 사용할 사용자 ID (preferred_username, 없으면 sub)
+func (i *Identity) UserID() string {
+	if i.Username != "" {
+		return i.Username
+	}
+	return i.Subject
+}
+
+// UserGroups 그룹 기반 네임스페이스/역할 판단을 위한 UserGroups 반환
+func (i *Identity) UserGroups() *UserGroups {
+	return &UserGroups{
+		UserID:   i.Subject,
+		Username: i.Username,
+		Groups:   i.Groups,
+		Email:    i.Email,
+	}
+}
+
+// Authenticate Bearer 액세스 토큰을 검증하고 사용자 정보 구성
+// 클레임 파이프라인: 토큰 검증 → 그룹 클레임(OIDC_GROUPS_CLAIM) 추출 → userinfo 보강(선택) → UserGroups 정규화
+// 로컬 JWKS 검증을 우선하며, OIDC_USERINFO_FALLBACK이 켜져 있으면 로컬 검증에 실패한 토큰(불투명 토큰 등)을 userinfo로 재확인
+func (p *OIDCProvider) Authenticate(ctx context.Context, rawAccessToken string) (*Identity, error) {
+	cfg := config.Get()
+	claims := make(map[string]any)
+	var tokenExpiry time.Time
+
+	token, err := p.VerifyAccessToken(ctx, rawAccessToken)
+	switch {
+	case err == nil:
+		if err := token.Claims(&claims); err != nil {
+			return nil, fmt.Errorf("failed to parse token claims: %v", err)
+		}
+		tokenExpiry = token.Expiry
+
+	// 만료된 토큰은 IdP에 물어볼 필요 없이 거부
+	case errors.Is(err, ErrTokenExpired) || !cfg.OIDC.UserInfoFallback:
+		return nil, err
+
+	default:
+		userInfo, fetchErr := p.FetchUserInfo(ctx, rawAccessToken)
+		if fetchErr != nil {
+			return nil, fmt.Errorf("%v (userinfo fallback: %w)", err, fetchErr)
+		}
+		if err := userInfo.Claims(&claims); err != nil {
+			return nil, fmt.Errorf("failed to decode userinfo response: %v", err)
+		}
+	}
+
+	userGroups := userGroupsFromClaims(claims)
+	if userGroups.UserID == "" {
+		return nil, fmt.Errorf("token does not contain a subject")
+	}
+
+	// fallback 경로의 클레임은 이미 userinfo이므로 로컬 검증된 토큰만 보강
+	if cfg.OIDC.UserInfoEnrichment && !tokenExpiry.IsZero() {
+		if err := p.enrichFromUserInfo(ctx, userGroups, rawAccessToken, tokenExpiry); err != nil {
+			logger.WarnWithContext(ctx, "Failed to enrich identity from userinfo", map[string]any{
+				"user_id": userGroups.UserID,
+				"error":   err.Error(),
+			})
+		}
+	}
+
+	name, _ := claims["name"].(string)
+	return &Identity{
+		Subject:  userGroups.UserID,
+		Username: userGroups.Username,
+		Email:    userGroups.Email,
+		Name:     name,
+		Groups:   userGroups.Groups,
+		Role:     userGroups.GetUserRole(),
+
+		NamespaceRoles: userGroups.NamespaceRoles(),
+
+		Token: rawAccessToken,
+	}, nil
+}
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"portal-backend/internal/models"
+)
+
+// 로그인 세션 저장소 종류
+const (
+	LoginStoreMemory     = "memory"
+	LoginStoreKubernetes = "kubernetes"
+)
+
+// ErrSessionConflict 조건부 갱신 시 저장된 세션의 버전이 달라졌을 때 반환되는 에러
+var ErrSessionConflict = errors.New("login session was modified concurrently")
+
+// PendingLogin /auth/login에서 시작되어 콜백을 기다리는 로그인 요청
+type PendingLogin struct {
+	CodeVerifier string
+	Nonce        string
+	Redirect     string
+	ExpiresAt    time.Time
+}
+
+// StoredSession 저장소에서 읽은 로그인 세션과 조건부 갱신에 사용할 버전
+type StoredSession struct {
+	Session             models.Session
+	RefreshClaimedUntil time.Time // 토큰 갱신을 선점한 요청의 선점 만료 시간 (갱신 중이 아니면 zero)
+	Version             string    // UpdateSession 조건 (성공하면 새 버전으로 바뀜)
+}
+
+// LoginStore 로그인 세션과 진행 중인 로그인 요청 저장소
+// 레플리카 간 공유 저장소를 사용하면 재시작이나 세션 고정 없는 다중 레플리카 환경에서도 로그인이 유지됨
+type LoginStore interface {
+	// SavePending 로그인 요청 저장
+	SavePending(state string, login *PendingLogin) error
+	// TakePending 로그인 요청을 조회하면서 제거 (state는 한 번만 사용, 없으면 ErrSessionNotFound)
+	TakePending(state string) (*PendingLogin, error)
+	// CreateSession 새 세션 저장
+	CreateSession(session *models.Session) error
+	// GetSession 세션 조회 (없으면 ErrSessionNotFound)
+	GetSession(sessionID string) (*StoredSession, error)
+	// UpdateSession stored.Version이 저장된 버전과 같을 때만 갱신 (다르면 ErrSessionConflict, 없으면 ErrSessionNotFound)
+	UpdateSession(stored *StoredSession) error
+	// DeleteSession 세션 제거 (없어도 에러 아님)
+	DeleteSession(sessionID string) error
+	// Prune 만료된 로그인 요청과 세션 제거
+	Prune(now time.Time) error
+}
+
+// MemoryLoginStore 프로세스 메모리 기반 로그인 저장소 (단일 레플리카/개발 환경용)
+type MemoryLoginStore struct {
+	mu       sync.Mutex
+	sessions map[string]*StoredSession
+	pending  map[string]*PendingLogin // state별 로그인 요청
+	version  int
+}
+
+// NewMemoryLoginStore 새로운 메모리 로그인 저장소 생성
+func NewMemoryLoginStore() *MemoryLoginStore {
+	return &MemoryLoginStore{
+		sessions: make(map[string]*StoredSession),
+		pending:  make(map[string]*PendingLogin),
+	}
+}
+
+// nextVersionLocked 새 버전 문자열 (mu를 잡은 상태에서 호출)
+func (s *MemoryLoginStore) nextVersionLocked() string {
+	s.version++
+	return strconv.Itoa(s.version)
+}
+
+// SavePending 로그인 요청 저장
+func (s *MemoryLoginStore) SavePending(state string, login *PendingLogin) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := *login
+	s.pending[state] = &saved
+	return nil
+}
+
+// TakePending 로그인 요청을 조회하면서 제거
+func (s *MemoryLoginStore) TakePending(state string) (*PendingLogin, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	login, exists := s.pending[state]
+	if !exists {
+		return nil, ErrSessionNotFound
+	}
+	delete(s.pending, state)
+	return login, nil
+}
+
+// CreateSession 새 세션 저장
+func (s *MemoryLoginStore) CreateSession(session *models.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.sessions[session.SessionID]; exists {
+		return fmt.Errorf("login session %s already exists", session.SessionID)
+	}
+	s.sessions[session.SessionID] = &StoredSession{Session: *session, Version: s.nextVersionLocked()}
+	return nil
+}
+
+// GetSession 세션 조회 (저장된 값의 복사본 반환)
+func (s *MemoryLoginStore) GetSession(sessionID string) (*StoredSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, exists := s.sessions[sessionID]
+	if !exists {
+		return nil, ErrSessionNotFound
+	}
+	snapshot := *stored
+	return &snapshot, nil
+}
+
+// UpdateSession 버전이 일치할 때만 세션 갱신
+func (s *MemoryLoginStore) UpdateSession(stored *StoredSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, exists := s.sessions[stored.Session.SessionID]
+	if !exists {
+		return ErrSessionNotFound
+	}
+	if current.Version != stored.Version {
+		return ErrSessionConflict
+	}
+	stored.Version = s.nextVersionLocked()
+	updated := *stored
+	s.sessions[stored.Session.SessionID] = &updated
+	return nil
+}
+
+// DeleteSession 세션 제거
+func (s *MemoryLoginStore) DeleteSession(sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, sessionID)
+	return nil
+}
+
+// Prune 만료된 로그인 요청과 세션 제거
+func (s *MemoryLoginStore) Prune(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for state, login := range s.pending {
+		if now.After(login.ExpiresAt) {
+			delete(s.pending, state)
+		}
+	}
+	for sessionID, stored := range s.sessions {
+		if now.After(SessionExpiry(&stored.Session)) {
+			delete(s.sessions, sessionID)
+		}
+	}
+	return nil
+}
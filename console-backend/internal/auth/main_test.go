@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"portal-backend/internal/config"
+)
+
+// TestMain 클레임/역할 계산이 참조하는 전역 설정을 기본 매핑으로 로드
+func TestMain(m *testing.M) {
+	env := map[string]string{
+		"OIDC_CLIENT_ID":      "portal-app",
+		"OIDC_CLIENT_SECRET":  "secret",
+		"OIDC_ISSUER_URL":     "https://keycloak.example.com/realms/test",
+		"OIDC_REDIRECT_URL":   "https://portal.example.com/auth/callback",
+		"JWT_SECRET_KEY":      "test-secret",
+		"OIDC_GROUPS_CLAIM":   "groups",
+		"GROUP_MAPPINGS_FILE": "",
+		"CLUSTERS_FILE":       "",
+	}
+	for key, value := range env {
+		os.Setenv(key, value)
+	}
+
+	if _, err := config.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load test config: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
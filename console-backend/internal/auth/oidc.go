@@ -5,11 +5,14 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"golang.org/x/oauth2"
@@ -27,10 +30,35 @@ type OIDCConfig struct {
 
 // OIDCProvider OIDC 제공자
 type OIDCProvider struct {
-	config       *OIDCConfig
-	oauth2Config *oauth2.Config
-	provider     *oidc.Provider
-	verifier     *oidc.IDTokenVerifier
+	config         *OIDCConfig
+	oauth2Config   *oauth2.Config
+	provider       *oidc.Provider
+	verifier       *oidc.IDTokenVerifier
+	accessVerifier *oidc.IDTokenVerifier // Bearer 액세스 토큰 검증기
+	endpoints      *ProviderEndpoints
+	userInfoCache  *userInfoCache // userinfo 보강 결과 캐시
+}
+
+// ProviderEndpoints discovery 문서(.well-known/openid-configuration)에서 읽은 엔드포인트
+type ProviderEndpoints struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"` // IdP가 RP-initiated logout을 지원하지 않으면 비어 있음
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// discoveredEndpoints NewOIDCProvider에서 discovery한 엔드포인트 (토큰 교환/갱신 등 패키지 함수에서 사용)
+var discoveredEndpoints atomic.Pointer[ProviderEndpoints]
+
+// Endpoints discovery한 OIDC 엔드포인트 반환
+func Endpoints() (*ProviderEndpoints, error) {
+	endpoints := discoveredEndpoints.Load()
+	if endpoints == nil {
+		return nil, fmt.Errorf("OIDC provider endpoints have not been discovered")
+	}
+	return endpoints, nil
 }
 
 // NewOIDCProvider 새로운 OIDC 제공자 생성
@@ -49,32 +77,64 @@ func NewOIDCProvider() (*OIDCProvider, error) {
 		return nil, fmt.Errorf("OIDC provider 생성 실패: %v", err)
 	}
 
+	var endpoints ProviderEndpoints
+	if err := provider.Claims(&endpoints); err != nil {
+		return nil, fmt.Errorf("OIDC discovery 문서 파싱 실패: %v", err)
+	}
+	if endpoints.TokenEndpoint == "" {
+		return nil, fmt.Errorf("OIDC discovery 문서에 token_endpoint가 없습니다")
+	}
+	discoveredEndpoints.Store(&endpoints)
+
+	endpoint := provider.Endpoint()
+	switch cfg.OIDC.ClientAuthMethod {
+	case config.ClientAuthBasic:
+		endpoint.AuthStyle = oauth2.AuthStyleInHeader
+	default:
+		// client_secret_post와 private_key_jwt는 폼 파라미터로 클라이언트 인증
+		endpoint.AuthStyle = oauth2.AuthStyleInParams
+	}
+
 	oauth2Config := &oauth2.Config{
 		ClientID:     oidcConfig.ClientID,
 		ClientSecret: oidcConfig.ClientSecret,
 		RedirectURL:  oidcConfig.RedirectURL,
-		Endpoint:     provider.Endpoint(),
+		Endpoint:     endpoint,
 		Scopes:       []string{oidc.ScopeOpenID, "profile", "email", "offline_access"},
 	}
+	if cfg.OIDC.ClientAuthMethod == config.ClientAuthPrivateKey {
+		oauth2Config.ClientSecret = ""
+	}
 
 	verifier := provider.Verifier(&oidc.Config{ClientID: oidcConfig.ClientID})
 
 	return &OIDCProvider{
-		config:       oidcConfig,
-		oauth2Config: oauth2Config,
-		provider:     provider,
-		verifier:     verifier,
+		config:         oidcConfig,
+		oauth2Config:   oauth2Config,
+		provider:       provider,
+		verifier:       verifier,
+		accessVerifier: newAccessTokenVerifier(provider),
+		endpoints:      &endpoints,
+		userInfoCache:  newUserInfoCache(),
 	}, nil
 }
 
-// GetAuthURL 인증 URL 생성
-func (p *OIDCProvider) GetAuthURL(state string) string {
-	return p.oauth2Config.AuthCodeURL(state)
+// GetAuthURL 인증 URL 생성 (PKCE S256 challenge와 ID 토큰 nonce 포함)
+func (p *OIDCProvider) GetAuthURL(state, codeVerifier, nonce string) string {
+	return p.oauth2Config.AuthCodeURL(state, oauth2.S256ChallengeOption(codeVerifier), oidc.Nonce(nonce))
 }
 
-// ExchangeCode 토큰 교환
-func (p *OIDCProvider) ExchangeCode(ctx context.Context, code string) (*oauth2.Token, error) {
-	return p.oauth2Config.Exchange(ctx, code)
+// ExchangeCode 인가 코드를 토큰으로 교환 (GetAuthURL에 사용한 PKCE verifier 필요)
+func (p *OIDCProvider) ExchangeCode(ctx context.Context, code, codeVerifier string) (*oauth2.Token, error) {
+	opts, err := clientAssertionOptions(p.endpoints.TokenEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, oauth2.VerifierOption(codeVerifier))
+
+	// 토큰 엔드포인트 요청에 제한 시간이 있는 클라이언트 사용
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, tokenHTTPClient)
+	return p.oauth2Config.Exchange(ctx, code, opts...)
 }
 
 // VerifyIDToken ID 토큰 검증
@@ -87,6 +147,11 @@ func (p *OIDCProvider) GetIssuerURL() string {
 	return p.config.IssuerURL
 }
 
+// Endpoints discovery한 OIDC 엔드포인트 반환
+func (p *OIDCProvider) Endpoints() *ProviderEndpoints {
+	return p.endpoints
+}
+
 // GenerateRandomString 랜덤 문자열 생성
 func GenerateRandomString(length int) (string, error) {
 	b := make([]byte, length)
@@ -110,18 +175,21 @@ type TokenExchangeResponse struct {
 	ExpiresIn        int    `json:"expires_in"`
 	RefreshToken     string `json:"refresh_token,omitempty"`
 	RefreshExpiresIn int    `json:"refresh_expires_in,omitempty"`
+	IDToken          string `json:"id_token,omitempty"` // refresh_token 그랜트에서 IdP가 새 ID 토큰을 발급한 경우
 	TokenType        string `json:"token_type"`
 	IssuedTokenType  string `json:"issued_token_type,omitempty"`
 	Scope            string `json:"scope,omitempty"`
 }
 
-// ExchangeTokenForKubernetes portal-app 토큰을 kubernetes 클라이언트용 토큰으로 교환
-func ExchangeTokenForKubernetes(subjectToken string) (*TokenExchangeResponse, error) {
+// ExchangeTokenForKubernetes portal-app 토큰을 kubernetes 클라이언트용 토큰으로 교환 (RFC 8693)
+// audience가 비어 있으면 KUBERNETES_CLIENT_ID를 대상으로 교환하며,
+// 대상은 OIDC_TOKEN_EXCHANGE_PARAM에 따라 audience 또는 resource 파라미터로 전달
+func ExchangeTokenForKubernetes(subjectToken, audience string) (*TokenExchangeResponse, error) {
 	cfg := config.Get()
-	clientID := cfg.OIDC.ClientID
-	clientSecret := cfg.OIDC.ClientSecret
-	targetAudience := cfg.OIDC.KubernetesClientID
-	tokenEndpoint := cfg.OIDC.IssuerURL + "/protocol/openid-connect/token"
+	targetAudience := audience
+	if targetAudience == "" {
+		targetAudience = cfg.OIDC.KubernetesClientID
+	}
 
 	if targetAudience == "" {
 		return nil, fmt.Errorf("KUBERNETES_CLIENT_ID environment variable is required for token exchange")
@@ -130,39 +198,109 @@ func ExchangeTokenForKubernetes(subjectToken string) (*TokenExchangeResponse, er
 	data := url.Values{}
 	data.Set("grant_type", "urn:ietf:params:oauth:grant-type:token-exchange")
 	data.Set("subject_token", subjectToken)
-	data.Set("subject_token_type", "urn:ietf:params:oauth:token-type:access_token")
-	data.Set("requested_token_type", "urn:ietf:params:oauth:token-type:access_token")
-	data.Set("audience", targetAudience)
+	data.Set("subject_token_type", cfg.OIDC.SubjectTokenType)
+	data.Set("requested_token_type", cfg.OIDC.RequestedTokenType)
+	data.Set(cfg.OIDC.TokenExchangeParam, targetAudience)
 
-	req, err := http.NewRequest("POST", tokenEndpoint, strings.NewReader(data.Encode()))
+	tokenResp, err := postTokenRequest(data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create token exchange request: %v", err)
+		return nil, fmt.Errorf("token exchange failed: %w", err)
+	}
+	return tokenResp, nil
+}
+
+// RefreshKubernetesToken 토큰 교환으로 받은 refresh token으로 쿠버네티스용 토큰 갱신
+func RefreshKubernetesToken(refreshToken string) (*TokenExchangeResponse, error) {
+	if refreshToken == "" {
+		return nil, fmt.Errorf("refresh token is empty")
 	}
 
-	auth := base64.StdEncoding.EncodeToString([]byte(clientID + ":" + clientSecret))
-	req.Header.Set("Authorization", "Basic "+auth)
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", refreshToken)
+
+	tokenResp, err := postTokenRequest(data)
+	if err != nil {
+		return nil, fmt.Errorf("token refresh failed: %w", err)
+	}
+	return tokenResp, nil
+}
+
+// TokenEndpointError 토큰 엔드포인트가 200 이외의 상태로 응답한 에러
+type TokenEndpointError struct {
+	StatusCode int
+	ErrorCode  string // OAuth2 error 필드 (예: invalid_grant)
+	Body       string
+}
+
+func (e *TokenEndpointError) Error() string {
+	return fmt.Sprintf("token endpoint returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// IsGrantRejected IdP가 그랜트(리프레시 토큰 등)를 거부했는지 확인
+// invalid_grant 또는 400/401 응답만 해당하며, 네트워크 오류나 5xx는 일시적인 실패로 간주
+func IsGrantRejected(err error) bool {
+	var endpointErr *TokenEndpointError
+	if !errors.As(err, &endpointErr) {
+		return false
+	}
+	return endpointErr.ErrorCode == "invalid_grant" ||
+		endpointErr.StatusCode == http.StatusBadRequest ||
+		endpointErr.StatusCode == http.StatusUnauthorized
+}
+
+// tokenRequestTimeout IdP 토큰 엔드포인트 요청 제한 시간
+// 응답하지 않는 IdP 때문에 요청 핸들러나 토큰 갱신 루틴이 무한히 대기하지 않도록 함
+const tokenRequestTimeout = 10 * time.Second
+
+// tokenHTTPClient 토큰 엔드포인트 요청용 HTTP 클라이언트 (연결 재사용)
+var tokenHTTPClient = &http.Client{Timeout: tokenRequestTimeout}
+
+// postTokenRequest 설정된 클라이언트 인증 방식으로 discovery한 토큰 엔드포인트에 요청
+func postTokenRequest(data url.Values) (*TokenExchangeResponse, error) {
+	endpoints, err := Endpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	basicAuth, err := applyClientAuth(endpoints.TokenEndpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", endpoints.TokenEndpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %v", err)
+	}
+
+	if basicAuth != "" {
+		req.Header.Set("Authorization", "Basic "+basicAuth)
+	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := tokenHTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to perform token exchange: %v", err)
+		return nil, fmt.Errorf("failed to perform token request: %v", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read token exchange response: %v", err)
+		return nil, fmt.Errorf("failed to read token response: %v", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, string(body))
+		var errorResp struct {
+			Error string `json:"error"`
+		}
+		_ = json.Unmarshal(body, &errorResp)
+		return nil, &TokenEndpointError{StatusCode: resp.StatusCode, ErrorCode: errorResp.Error, Body: string(body)}
 	}
 
 	var tokenResp TokenExchangeResponse
 	if err := json.Unmarshal(body, &tokenResp); err != nil {
-		return nil, fmt.Errorf("failed to parse token exchange response: %v", err)
+		return nil, fmt.Errorf("failed to parse token response: %v", err)
 	}
 
 	return &tokenResp, nil
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestIsGrantRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "invalid_grant", err: &TokenEndpointError{StatusCode: http.StatusBadRequest, ErrorCode: "invalid_grant"}, want: true},
+		{name: "invalid_grant with unexpected status", err: &TokenEndpointError{StatusCode: http.StatusInternalServerError, ErrorCode: "invalid_grant"}, want: true},
+		{name: "bad request", err: &TokenEndpointError{StatusCode: http.StatusBadRequest}, want: true},
+		{name: "unauthorized client", err: &TokenEndpointError{StatusCode: http.StatusUnauthorized, ErrorCode: "invalid_client"}, want: true},
+		{name: "wrapped rejection", err: fmt.Errorf("token refresh failed: %w", &TokenEndpointError{StatusCode: http.StatusBadRequest}), want: true},
+		{name: "server error", err: &TokenEndpointError{StatusCode: http.StatusServiceUnavailable}, want: false},
+		{name: "rate limited", err: &TokenEndpointError{StatusCode: http.StatusTooManyRequests}, want: false},
+		{name: "network error", err: errors.New("failed to perform token request: connection refused"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsGrantRejected(tt.err); got != tt.want {
+				t.Errorf("IsGrantRejected(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"regexp"
+	"slices"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"portal-backend/internal/config"
+)
+
+// ClusterAdminRole 클러스터 관리자 매핑 규칙으로 부여되는 역할 표시 이름
+const ClusterAdminRole = "cluster-admin"
+
+// DefaultRole 매핑되는 그룹이 없는 사용자의 역할
+const DefaultRole = "user"
+
+// RoleGrants 사용자 그룹을 매핑 규칙에 적용한 결과
+type RoleGrants struct {
+	ClusterAdmin   bool           // 클러스터 관리자 규칙과 일치
+	NamespaceRoles NamespaceRoles // 네임스페이스별 최고 역할
+}
+
+// roleMapper 설정된 그룹 매핑 규칙(OIDC.GroupMappings)을 컴파일한 매핑 엔진
+type roleMapper struct {
+	roles []string // 권한이 높은 순서
+	rules []compiledMappingRule
+}
+
+type compiledMappingRule struct {
+	config.GroupMappingRule
+	pattern *regexp.Regexp
+}
+
+var (
+	roleMapperOnce sync.Once
+	currentMapper  *roleMapper
+)
+
+// getRoleMapper 설정으로부터 매핑 엔진을 한 번만 구성 (패턴은 설정 로드 시 검증됨)
+func getRoleMapper() *roleMapper {
+	roleMapperOnce.Do(func() {
+		currentMapper = newRoleMapper(config.Get().OIDC.GroupMappings)
+	})
+	return currentMapper
+}
+
+// newRoleMapper 매핑 설정의 패턴을 컴파일하여 매핑 엔진 생성 (컴파일되지 않는 규칙은 제외)
+func newRoleMapper(mappings config.GroupMappingConfig) *roleMapper {
+	mapper := &roleMapper{roles: mappings.Roles}
+	for _, rule := range mappings.Rules {
+		pattern, err := rule.Pattern()
+		if err != nil {
+			continue
+		}
+		mapper.rules = append(mapper.rules, compiledMappingRule{GroupMappingRule: rule, pattern: pattern})
+	}
+	return mapper
+}
+
+// priority 역할의 우선순위 (낮을수록 높은 권한, 모르는 역할은 가장 낮음)
+func (m *roleMapper) priority(role string) int {
+	if index := slices.Index(m.roles, role); index >= 0 {
+		return index
+	}
+	return len(m.roles)
+}
+
+// highestRole 가장 높은 포털 역할
+func (m *roleMapper) highestRole() string {
+	if len(m.roles) == 0 {
+		return DefaultRole
+	}
+	return m.roles[0]
+}
+
+// resolve 그룹 목록에 모든 매핑 규칙을 적용하여 네임스페이스별 최고 역할 계산
+// 그룹 이름에서 만들어진 네임스페이스가 DNS-1123 라벨이 아니면(대문자, 밑줄 등) 무시
+func (m *roleMapper) resolve(groups []string) RoleGrants {
+	grants := RoleGrants{NamespaceRoles: make(NamespaceRoles)}
+
+	for _, group := range groups {
+		for _, rule := range m.rules {
+			match := rule.pattern.FindStringSubmatchIndex(group)
+			if match == nil {
+				continue
+			}
+
+			if rule.ClusterAdmin {
+				grants.ClusterAdmin = true
+				continue
+			}
+
+			namespace := string(rule.pattern.ExpandString(nil, rule.Namespace, group, match))
+			if len(validation.IsDNS1123Label(namespace)) > 0 {
+				continue
+			}
+			if existing, exists := grants.NamespaceRoles[namespace]; exists && m.priority(existing) <= m.priority(rule.Role) {
+				continue
+			}
+			grants.NamespaceRoles[namespace] = rule.Role
+		}
+	}
+
+	return grants
+}
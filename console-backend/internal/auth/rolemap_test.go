@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"reflect"
+	"testing"
+
+	"portal-backend/internal/config"
+)
+
+func TestRoleMapperResolve(t *testing.T) {
+	mapper := newRoleMapper(config.GroupMappingConfig{
+		Roles: []string{"admin", "developer", "viewer"},
+		Rules: []config.GroupMappingRule{
+			{Match: "cluster-admins", ClusterAdmin: true},
+			{Match: "/*/*/adm", Namespace: "$2", Role: "admin"},
+			{Match: "/*/*/dev", Namespace: "$2", Role: "developer"},
+			{Match: "/*/*/view", Namespace: "$2", Role: "viewer"},
+			{Match: `team-(?P<team>[a-z0-9-]+)-ops`, Type: config.PatternRegex, Namespace: "${team}-ops", Role: "developer"},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		groups []string
+		want   RoleGrants
+	}{
+		{
+			name:   "no groups",
+			groups: nil,
+			want:   RoleGrants{NamespaceRoles: NamespaceRoles{}},
+		},
+		{
+			name:   "per-namespace roles",
+			groups: []string{"/org/blue/adm", "/org/red/view"},
+			want:   RoleGrants{NamespaceRoles: NamespaceRoles{"blue": "admin", "red": "viewer"}},
+		},
+		{
+			name:   "highest role wins regardless of group order",
+			groups: []string{"/org/blue/view", "/org/blue/adm", "/org/blue/dev"},
+			want:   RoleGrants{NamespaceRoles: NamespaceRoles{"blue": "admin"}},
+		},
+		{
+			name:   "cluster admin",
+			groups: []string{"cluster-admins", "/org/blue/dev"},
+			want:   RoleGrants{ClusterAdmin: true, NamespaceRoles: NamespaceRoles{"blue": "developer"}},
+		},
+		{
+			name:   "regex named capture expands namespace template",
+			groups: []string{"team-payments-ops"},
+			want:   RoleGrants{NamespaceRoles: NamespaceRoles{"payments-ops": "developer"}},
+		},
+		{
+			name:   "expansions that are not DNS-1123 labels are dropped",
+			groups: []string{"/org/Blue/adm", "/org/blue_team/dev", "/org//view", "/org/-red/adm", "/org/green/view"},
+			want:   RoleGrants{NamespaceRoles: NamespaceRoles{"green": "viewer"}},
+		},
+		{
+			name:   "unmatched groups are ignored",
+			groups: []string{"/org/blue/owner", "developers"},
+			want:   RoleGrants{NamespaceRoles: NamespaceRoles{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapper.resolve(tt.groups); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolve(%q) = %#v, want %#v", tt.groups, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetermineDefaultNamespace(t *testing.T) {
+	tests := []struct {
+		name   string
+		groups []string
+		want   string
+	}{
+		{name: "no mapped groups", groups: []string{"developers"}, want: "default"},
+		{name: "single namespace", groups: []string{"/org/blue/view"}, want: "blue"},
+		{name: "highest role wins", groups: []string{"/org/blue/view", "/org/red/adm", "/org/green/dev"}, want: "red"},
+		{name: "tie on highest role falls back to default", groups: []string{"/org/blue/dev", "/org/red/dev", "/org/green/view"}, want: "default"},
+		{name: "lower role tie does not matter", groups: []string{"/org/blue/adm", "/org/red/view", "/org/green/view"}, want: "blue"},
+		{name: "cluster admin uses default", groups: []string{"cluster-admins", "/org/blue/adm"}, want: "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userGroups := &UserGroups{UserID: "user-1", Groups: tt.groups}
+			if got := userGroups.DetermineDefaultNamespace(); got != tt.want {
+				t.Errorf("DetermineDefaultNamespace(%q) = %q, want %q", tt.groups, got, tt.want)
+			}
+		})
+	}
+}
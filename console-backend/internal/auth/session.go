@@ -0,0 +1,381 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+
+	"portal-backend/internal/config"
+	"portal-backend/internal/logger"
+	"portal-backend/internal/models"
+)
+
+// SessionCookieName 서버 측 로그인 세션을 가리키는 HttpOnly 쿠키 이름
+const SessionCookieName = "portal-jwt"
+
+// loginStateTTL 로그인 시작부터 콜백까지 허용하는 시간
+const loginStateTTL = 10 * time.Minute
+
+// accessTokenRefreshLeeway 만료가 이 시간 이내로 남은 액세스 토큰은 사용 전에 갱신
+const accessTokenRefreshLeeway = 30 * time.Second
+
+// ErrSessionNotFound 로그인 세션이 없거나 만료됨
+var ErrSessionNotFound = errors.New("login session not found or expired")
+
+// SessionClaims portal-jwt 쿠키의 클레임 (토큰은 포함하지 않고 서버 측 세션 ID만 가리킴)
+type SessionClaims struct {
+	SessionID string `json:"sid"`
+	UserID    string `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
+// refreshClaimTTL 토큰 갱신 선점 유효 시간 (선점한 레플리카가 중단되면 이 시간이 지난 뒤 다른 요청이 갱신)
+const refreshClaimTTL = 2 * tokenRequestTimeout
+
+// refreshPollInterval 다른 레플리카가 갱신 중일 때 결과를 다시 확인하는 간격
+const refreshPollInterval = 250 * time.Millisecond
+
+// SessionManager 인가 코드 로그인(BFF) 세션 관리
+// 액세스/리프레시/ID 토큰은 서버 측 저장소에만 저장하고 브라우저에는 세션 ID를 담은 서명 쿠키만 전달
+type SessionManager struct {
+	provider *OIDCProvider
+	store    LoginStore
+
+	mu           sync.Mutex
+	refreshLocks map[string]*refreshLock // 세션별 프로세스 내 갱신 잠금 (레플리카 간에는 저장소의 선점으로 직렬화)
+}
+
+type refreshLock struct {
+	mu      sync.Mutex
+	waiters int // 잠금을 기다리거나 보유한 요청 수 (0이 되면 맵에서 제거)
+}
+
+// NewSessionManager 새로운 로그인 세션 관리자 생성
+func NewSessionManager(provider *OIDCProvider, store LoginStore) *SessionManager {
+	return &SessionManager{
+		provider:     provider,
+		store:        store,
+		refreshLocks: make(map[string]*refreshLock),
+	}
+}
+
+// BeginLogin state, PKCE verifier, nonce를 만들어 저장하고 IdP 인증 URL 반환
+// redirect는 로그인 후 돌아갈 프론트엔드 경로 (/로 시작하는 상대 경로만 허용)
+func (m *SessionManager) BeginLogin(redirect string) (authURL, state string, err error) {
+	state, err = GenerateRandomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := GenerateRandomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	codeVerifier := oauth2.GenerateVerifier()
+
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.HasPrefix(redirect, "/\\") {
+		redirect = ""
+	}
+
+	now := time.Now()
+	if err := m.store.Prune(now); err != nil {
+		// 정리 실패는 로그인을 막지 않음 (다음 로그인에서 다시 시도)
+		logger.Warn(fmt.Sprintf("Failed to prune expired login sessions: %v", err))
+	}
+	if err := m.store.SavePending(state, &PendingLogin{
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		Redirect:     redirect,
+		ExpiresAt:    now.Add(loginStateTTL),
+	}); err != nil {
+		return "", "", fmt.Errorf("failed to save login request: %v", err)
+	}
+
+	return m.provider.GetAuthURL(state, codeVerifier, nonce), state, nil
+}
+
+// CompleteLogin 콜백의 state를 확인하고 인가 코드를 교환하여 서버 측 세션 생성
+// 반환하는 redirect는 OIDC_POST_LOGIN_REDIRECT_URL에 BeginLogin의 경로를 붙인 URL
+func (m *SessionManager) CompleteLogin(ctx context.Context, state, code string) (*models.Session, string, error) {
+	// state는 한 번만 사용 (다른 레플리카에서 이미 사용했으면 실패)
+	login, err := m.store.TakePending(state)
+	if errors.Is(err, ErrSessionNotFound) || (err == nil && time.Now().After(login.ExpiresAt)) {
+		return nil, "", fmt.Errorf("login state is invalid or expired")
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load login request: %v", err)
+	}
+
+	token, err := m.provider.ExchangeCode(ctx, code, login.CodeVerifier)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to exchange authorization code: %v", err)
+	}
+
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, "", fmt.Errorf("token response does not contain an id_token")
+	}
+	idToken, err := m.provider.VerifyIDToken(ctx, rawIDToken)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to verify id_token: %v", err)
+	}
+	if idToken.Nonce != login.Nonce {
+		return nil, "", fmt.Errorf("id_token nonce does not match the login request")
+	}
+
+	// API 요청과 같은 방식으로 액세스 토큰을 검증하여 사용자 확인
+	identity, err := m.provider.Authenticate(ctx, token.AccessToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sessionID, err := GenerateRandomString(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	session := &models.Session{
+		SessionID:    sessionID,
+		AccessToken:  token.AccessToken,
+		IDToken:      rawIDToken,
+		RefreshToken: token.RefreshToken,
+		UserID:       identity.UserID(),
+		ExpiresAt:    token.Expiry,
+		State:        state,
+		CreatedAt:    time.Now(),
+	}
+	if err := m.store.CreateSession(session); err != nil {
+		return nil, "", fmt.Errorf("failed to save login session: %v", err)
+	}
+
+	return session, postLoginRedirectURL(login.Redirect), nil
+}
+
+// Get 세션 ID로 로그인 세션 조회 (JWT_SESSION_TTL_SECONDS가 지난 세션은 제거)
+func (m *SessionManager) Get(sessionID string) (*models.Session, error) {
+	stored, err := m.getStored(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return &stored.Session, nil
+}
+
+// getStored 저장소에서 세션을 조회하고 만료된 세션은 제거
+func (m *SessionManager) getStored(sessionID string) (*StoredSession, error) {
+	stored, err := m.store.GetSession(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(SessionExpiry(&stored.Session)) {
+		if err := m.store.DeleteSession(sessionID); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to delete expired login session: %v", err))
+		}
+		return nil, ErrSessionNotFound
+	}
+	return stored, nil
+}
+
+// Delete 로그인 세션 제거 (로그아웃), 제거된 세션 반환
+func (m *SessionManager) Delete(sessionID string) *models.Session {
+	stored, err := m.store.GetSession(sessionID)
+	if err != nil {
+		return nil
+	}
+	if err := m.store.DeleteSession(sessionID); err != nil {
+		logger.Warn(fmt.Sprintf("Failed to delete login session: %v", err))
+	}
+	return &stored.Session
+}
+
+// lockRefresh 세션별 갱신 잠금을 획득하고 해제 함수 반환 (다른 세션의 갱신은 서로 기다리지 않음)
+func (m *SessionManager) lockRefresh(sessionID string) func() {
+	m.mu.Lock()
+	lock, exists := m.refreshLocks[sessionID]
+	if !exists {
+		lock = &refreshLock{}
+		m.refreshLocks[sessionID] = lock
+	}
+	lock.waiters++
+	m.mu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+
+		m.mu.Lock()
+		lock.waiters--
+		if lock.waiters == 0 {
+			delete(m.refreshLocks, sessionID)
+		}
+		m.mu.Unlock()
+	}
+}
+
+// AccessToken 세션의 액세스 토큰 반환 (만료가 임박하면 리프레시 토큰으로 먼저 갱신)
+// IdP 장애로 갱신하지 못해도 기존 토큰이 아직 유효하면 그대로 사용
+func (m *SessionManager) AccessToken(sessionID string) (string, error) {
+	session, err := m.Get(sessionID)
+	if err != nil {
+		return "", err
+	}
+	if session.ExpiresAt.IsZero() || time.Until(session.ExpiresAt) > accessTokenRefreshLeeway {
+		return session.AccessToken, nil
+	}
+
+	refreshed, err := m.refresh(sessionID, false)
+	if err != nil {
+		if !errors.Is(err, ErrSessionNotFound) && time.Now().Before(session.ExpiresAt) {
+			return session.AccessToken, nil
+		}
+		return "", err
+	}
+	return refreshed.AccessToken, nil
+}
+
+// Refresh 리프레시 토큰으로 세션의 토큰 갱신
+// IdP가 리프레시 토큰을 거부하면(invalid_grant, 400/401) 세션을 제거하여 다시 로그인하도록 하고,
+// 네트워크 오류나 5xx는 세션을 유지한 채 ErrSessionNotFound가 아닌 에러로 반환
+func (m *SessionManager) Refresh(sessionID string) (*models.Session, error) {
+	return m.refresh(sessionID, true)
+}
+
+// refresh 토큰 갱신 (force가 아니면 대기하는 동안 다른 요청이 이미 갱신한 결과 사용)
+// 같은 프로세스의 요청은 세션별 잠금으로, 다른 레플리카의 요청은 저장소의 조건부 갱신으로 기록한 선점으로 직렬화하여
+// 회전되는 리프레시 토큰을 두 번 사용하지 않도록 함
+func (m *SessionManager) refresh(sessionID string, force bool) (*models.Session, error) {
+	unlock := m.lockRefresh(sessionID)
+	defer unlock()
+
+	deadline := time.Now().Add(refreshClaimTTL)
+	for {
+		stored, err := m.getStored(sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if !force && time.Until(stored.Session.ExpiresAt) > accessTokenRefreshLeeway {
+			return &stored.Session, nil
+		}
+
+		now := time.Now()
+		if now.After(deadline) {
+			return nil, fmt.Errorf("token refresh timed out waiting for another replica")
+		}
+
+		// 다른 레플리카가 갱신 중이면 그 결과를 기다림
+		if now.Before(stored.RefreshClaimedUntil) {
+			force = false
+			time.Sleep(refreshPollInterval)
+			continue
+		}
+
+		if stored.Session.RefreshToken == "" {
+			m.Delete(sessionID)
+			return nil, fmt.Errorf("%w: session has no refresh token", ErrSessionNotFound)
+		}
+
+		// 갱신 선점 (그 사이 다른 요청이 세션을 바꿨으면 다시 읽음)
+		stored.RefreshClaimedUntil = now.Add(refreshClaimTTL)
+		if err := m.store.UpdateSession(stored); errors.Is(err, ErrSessionConflict) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to claim token refresh: %w", err)
+		}
+
+		return m.redeemRefreshToken(stored)
+	}
+}
+
+// redeemRefreshToken 선점한 세션의 리프레시 토큰으로 토큰을 갱신하고 선점 해제
+func (m *SessionManager) redeemRefreshToken(stored *StoredSession) (*models.Session, error) {
+	sessionID := stored.Session.SessionID
+
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", stored.Session.RefreshToken)
+
+	tokenResp, err := postTokenRequest(data)
+	if err != nil {
+		if IsGrantRejected(err) {
+			m.Delete(sessionID)
+			return nil, fmt.Errorf("%w: token refresh failed: %v", ErrSessionNotFound, err)
+		}
+		// 일시적인 실패는 세션을 유지하고 선점만 해제 (해제하지 못해도 선점은 만료됨)
+		stored.RefreshClaimedUntil = time.Time{}
+		if releaseErr := m.store.UpdateSession(stored); releaseErr != nil {
+			logger.Warn(fmt.Sprintf("Failed to release token refresh claim: %v", releaseErr))
+		}
+		return nil, fmt.Errorf("token refresh failed: %w", err)
+	}
+
+	stored.Session.AccessToken = tokenResp.AccessToken
+	stored.Session.ExpiresAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	if tokenResp.RefreshToken != "" {
+		stored.Session.RefreshToken = tokenResp.RefreshToken // 리프레시 토큰 회전
+	}
+	if tokenResp.IDToken != "" {
+		stored.Session.IDToken = tokenResp.IDToken
+	}
+	stored.RefreshClaimedUntil = time.Time{}
+
+	if err := m.store.UpdateSession(stored); err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save refreshed tokens: %w", err)
+	}
+	return &stored.Session, nil
+}
+
+// IssueCookieToken 세션을 가리키는 portal-jwt 쿠키 값 서명 (HS256, JWT_SECRET_KEY)
+func (m *SessionManager) IssueCookieToken(session *models.Session) (string, error) {
+	claims := SessionClaims{
+		SessionID: session.SessionID,
+		UserID:    session.UserID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   session.UserID,
+			IssuedAt:  jwt.NewNumericDate(session.CreatedAt),
+			ExpiresAt: jwt.NewNumericDate(SessionExpiry(session)),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(config.Get().JWT.SecretKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign session cookie: %v", err)
+	}
+	return signed, nil
+}
+
+// ParseCookieToken portal-jwt 쿠키 값의 서명과 만료 확인 (실패하면 ErrSessionNotFound로 감싸서 반환)
+func (m *SessionManager) ParseCookieToken(raw string) (*SessionClaims, error) {
+	claims := &SessionClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+		return []byte(config.Get().JWT.SecretKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid session cookie: %v", ErrSessionNotFound, err)
+	}
+	if claims.SessionID == "" {
+		return nil, fmt.Errorf("%w: invalid session cookie: missing sid", ErrSessionNotFound)
+	}
+	return claims, nil
+}
+
+// SessionExpiry 로그인 세션 만료 시간 (생성 후 JWT_SESSION_TTL_SECONDS)
+func SessionExpiry(session *models.Session) time.Time {
+	return session.CreatedAt.Add(time.Duration(config.Get().JWT.SessionTTLSeconds) * time.Second)
+}
+
+// postLoginRedirectURL 로그인 후 이동할 프론트엔드 URL
+func postLoginRedirectURL(path string) string {
+	base := config.Get().OIDC.PostLoginRedirectURL
+	if path == "" {
+		return base
+	}
+	return strings.TrimSuffix(base, "/") + path
+}
@@ -0,0 +1,306 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"portal-backend/internal/config"
+	"portal-backend/internal/models"
+)
+
+// sessionTTL 로그인 세션 유효 시간 (JWT_SESSION_TTL_SECONDS)
+func sessionTTL() time.Duration {
+	return time.Duration(config.Get().JWT.SessionTTLSeconds) * time.Second
+}
+
+// fakeTokenEndpoint 리프레시 토큰을 회전시키는 IdP 토큰 엔드포인트
+// 이미 사용한 리프레시 토큰은 Keycloak처럼 invalid_grant로 거부
+type fakeTokenEndpoint struct {
+	mu           sync.Mutex
+	refreshToken string
+	status       int           // 0이 아니면 이 상태 코드로 실패 응답
+	delay        time.Duration // 응답 지연 (동시 갱신이 겹치도록)
+	calls        atomic.Int32
+}
+
+func (f *fakeTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	calls := f.calls.Add(1)
+	time.Sleep(f.delay)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		fmt.Fprint(w, `{"error":"temporarily_unavailable"}`)
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("refresh_token") != f.refreshToken {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid_grant"}`)
+		return
+	}
+
+	f.refreshToken = fmt.Sprintf("refresh-%d", calls)
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token":  fmt.Sprintf("access-%d", calls),
+		"refresh_token": f.refreshToken,
+		"expires_in":    300,
+		"token_type":    "Bearer",
+	})
+}
+
+// newTestSessionManager 메모리 저장소와 가짜 토큰 엔드포인트를 사용하는 세션 관리자
+func newTestSessionManager(t *testing.T, endpoint *fakeTokenEndpoint) (*SessionManager, *MemoryLoginStore) {
+	t.Helper()
+	server := httptest.NewServer(endpoint)
+	t.Cleanup(server.Close)
+
+	previous := discoveredEndpoints.Load()
+	discoveredEndpoints.Store(&ProviderEndpoints{TokenEndpoint: server.URL})
+	t.Cleanup(func() { discoveredEndpoints.Store(previous) })
+
+	store := NewMemoryLoginStore()
+	return NewSessionManager(nil, store), store
+}
+
+// createTestSession 액세스 토큰 만료가 expiresIn 남은 세션 생성
+func createTestSession(t *testing.T, store LoginStore, refreshToken string, expiresIn time.Duration) *models.Session {
+	t.Helper()
+	session := &models.Session{
+		SessionID:    "session-1",
+		AccessToken:  "access-0",
+		RefreshToken: refreshToken,
+		UserID:       "alice",
+		ExpiresAt:    time.Now().Add(expiresIn),
+		CreatedAt:    time.Now(),
+	}
+	if err := store.CreateSession(session); err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	return session
+}
+
+func TestSessionManagerConcurrentRefreshRedeemsOnce(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{refreshToken: "refresh-0", delay: 50 * time.Millisecond}
+	manager, store := newTestSessionManager(t, endpoint)
+	session := createTestSession(t, store, "refresh-0", 10*time.Second)
+
+	const requests = 10
+	tokens := make([]string, requests)
+	errs := make([]error, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i], errs[i] = manager.AccessToken(session.SessionID)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < requests; i++ {
+		if errs[i] != nil {
+			t.Fatalf("AccessToken() error = %v", errs[i])
+		}
+		if tokens[i] != "access-1" {
+			t.Errorf("AccessToken() = %q, want access-1", tokens[i])
+		}
+	}
+	if calls := endpoint.calls.Load(); calls != 1 {
+		t.Errorf("token endpoint called %d times, want 1", calls)
+	}
+	if len(manager.refreshLocks) != 0 {
+		t.Errorf("refresh locks not released: %d left", len(manager.refreshLocks))
+	}
+}
+
+func TestSessionManagerRefreshWaitsForClaimHolder(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{refreshToken: "refresh-0"}
+	manager, store := newTestSessionManager(t, endpoint)
+	session := createTestSession(t, store, "refresh-0", 10*time.Second)
+
+	// 다른 레플리카가 갱신을 선점한 상태
+	stored, _ := store.GetSession(session.SessionID)
+	stored.RefreshClaimedUntil = time.Now().Add(refreshClaimTTL)
+	if err := store.UpdateSession(stored); err != nil {
+		t.Fatalf("UpdateSession() error = %v", err)
+	}
+
+	// 선점한 레플리카가 갱신 결과를 기록하고 선점 해제
+	go func() {
+		time.Sleep(2 * refreshPollInterval)
+		stored, _ := store.GetSession(session.SessionID)
+		stored.Session.AccessToken = "access-other"
+		stored.Session.RefreshToken = "refresh-other"
+		stored.Session.ExpiresAt = time.Now().Add(5 * time.Minute)
+		stored.RefreshClaimedUntil = time.Time{}
+		if err := store.UpdateSession(stored); err != nil {
+			t.Errorf("UpdateSession() error = %v", err)
+		}
+	}()
+
+	token, err := manager.AccessToken(session.SessionID)
+	if err != nil {
+		t.Fatalf("AccessToken() error = %v", err)
+	}
+	if token != "access-other" {
+		t.Errorf("AccessToken() = %q, want the other replica's access-other", token)
+	}
+	if calls := endpoint.calls.Load(); calls != 0 {
+		t.Errorf("token endpoint called %d times while another replica held the claim", calls)
+	}
+}
+
+func TestSessionManagerRefreshIgnoresExpiredClaim(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{refreshToken: "refresh-0"}
+	manager, store := newTestSessionManager(t, endpoint)
+	session := createTestSession(t, store, "refresh-0", 10*time.Second)
+
+	// 선점한 레플리카가 중단되어 선점이 만료된 상태
+	stored, _ := store.GetSession(session.SessionID)
+	stored.RefreshClaimedUntil = time.Now().Add(-time.Second)
+	if err := store.UpdateSession(stored); err != nil {
+		t.Fatalf("UpdateSession() error = %v", err)
+	}
+
+	refreshed, err := manager.Refresh(session.SessionID)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if refreshed.AccessToken != "access-1" || refreshed.RefreshToken != "refresh-1" {
+		t.Errorf("Refresh() tokens = (%q, %q), want (access-1, refresh-1)", refreshed.AccessToken, refreshed.RefreshToken)
+	}
+
+	stored, _ = store.GetSession(session.SessionID)
+	if !stored.RefreshClaimedUntil.IsZero() {
+		t.Errorf("refresh claim not released: %s", stored.RefreshClaimedUntil)
+	}
+}
+
+func TestSessionManagerRefreshRejectedDeletesSession(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{refreshToken: "refresh-current"}
+	manager, store := newTestSessionManager(t, endpoint)
+	session := createTestSession(t, store, "refresh-revoked", 10*time.Second)
+
+	if _, err := manager.Refresh(session.SessionID); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("Refresh() error = %v, want ErrSessionNotFound", err)
+	}
+	if _, err := store.GetSession(session.SessionID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("session still stored after the refresh token was rejected (err = %v)", err)
+	}
+}
+
+func TestSessionManagerRefreshTransientFailureKeepsSession(t *testing.T) {
+	endpoint := &fakeTokenEndpoint{refreshToken: "refresh-0", status: http.StatusServiceUnavailable}
+	manager, store := newTestSessionManager(t, endpoint)
+	session := createTestSession(t, store, "refresh-0", 10*time.Second)
+
+	_, err := manager.Refresh(session.SessionID)
+	if err == nil || errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("Refresh() error = %v, want a transient error", err)
+	}
+
+	stored, err := store.GetSession(session.SessionID)
+	if err != nil {
+		t.Fatalf("session removed after a transient failure: %v", err)
+	}
+	if !stored.RefreshClaimedUntil.IsZero() {
+		t.Errorf("refresh claim not released after a transient failure: %s", stored.RefreshClaimedUntil)
+	}
+
+	// 기존 토큰이 아직 유효하면 그대로 사용
+	token, err := manager.AccessToken(session.SessionID)
+	if err != nil || token != "access-0" {
+		t.Errorf("AccessToken() = (%q, %v), want the unexpired access-0", token, err)
+	}
+}
+
+func TestSessionManagerGetDeletesExpiredSession(t *testing.T) {
+	manager, store := newTestSessionManager(t, &fakeTokenEndpoint{})
+	session := &models.Session{
+		SessionID: "expired",
+		UserID:    "alice",
+		CreatedAt: time.Now().Add(-sessionTTL() - time.Minute),
+	}
+	if err := store.CreateSession(session); err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+
+	if _, err := manager.Get(session.SessionID); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("Get() error = %v, want ErrSessionNotFound", err)
+	}
+	if _, err := store.GetSession(session.SessionID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expired session still stored (err = %v)", err)
+	}
+}
+
+func TestCompleteLoginRejectsExpiredOrReusedState(t *testing.T) {
+	manager, store := newTestSessionManager(t, &fakeTokenEndpoint{})
+	if err := store.SavePending("expired-state", &PendingLogin{ExpiresAt: time.Now().Add(-time.Second)}); err != nil {
+		t.Fatalf("SavePending() error = %v", err)
+	}
+
+	for _, state := range []string{"expired-state", "expired-state", "unknown-state"} {
+		if _, _, err := manager.CompleteLogin(t.Context(), state, "code"); err == nil {
+			t.Errorf("CompleteLogin(%q) succeeded, want an invalid state error", state)
+		}
+	}
+}
+
+func TestMemoryLoginStorePrune(t *testing.T) {
+	store := NewMemoryLoginStore()
+	now := time.Now()
+
+	store.SavePending("expired", &PendingLogin{ExpiresAt: now.Add(-time.Second)})
+	store.SavePending("valid", &PendingLogin{ExpiresAt: now.Add(loginStateTTL)})
+	store.CreateSession(&models.Session{SessionID: "old", CreatedAt: now.Add(-sessionTTL() - time.Second)})
+	store.CreateSession(&models.Session{SessionID: "new", CreatedAt: now})
+
+	if err := store.Prune(now); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+
+	if _, err := store.TakePending("expired"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expired login request not pruned (err = %v)", err)
+	}
+	if _, err := store.TakePending("valid"); err != nil {
+		t.Errorf("valid login request pruned: %v", err)
+	}
+	if _, err := store.GetSession("old"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expired session not pruned (err = %v)", err)
+	}
+	if _, err := store.GetSession("new"); err != nil {
+		t.Errorf("active session pruned: %v", err)
+	}
+}
+
+func TestMemoryLoginStoreUpdateSessionConflict(t *testing.T) {
+	store := NewMemoryLoginStore()
+	store.CreateSession(&models.Session{SessionID: "session-1", CreatedAt: time.Now()})
+
+	first, _ := store.GetSession("session-1")
+	second, _ := store.GetSession("session-1")
+
+	first.Session.AccessToken = "first"
+	if err := store.UpdateSession(first); err != nil {
+		t.Fatalf("UpdateSession() error = %v", err)
+	}
+	second.Session.AccessToken = "second"
+	if err := store.UpdateSession(second); !errors.Is(err, ErrSessionConflict) {
+		t.Fatalf("stale UpdateSession() error = %v, want ErrSessionConflict", err)
+	}
+
+	stored, _ := store.GetSession("session-1")
+	if stored.Session.AccessToken != "first" {
+		t.Errorf("AccessToken = %q, want first", stored.Session.AccessToken)
+	}
+}
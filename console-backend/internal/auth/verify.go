@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	"portal-backend/internal/config"
+)
+
+// ErrTokenExpired 액세스 토큰이 만료되었을 때 반환되는 에러
+var ErrTokenExpired = errors.New("token has expired")
+
+// accessTokenClaims go-oidc IDToken이 노출하지 않는 추가 검증 클레임
+type accessTokenClaims struct {
+	AuthorizedParty string `json:"azp"`
+	Type            string `json:"typ"` // Keycloak 토큰 종류 (Bearer, ID, Refresh)
+}
+
+// newAccessTokenVerifier 액세스 토큰 검증기 생성
+// aud는 여러 값을 허용하기 위해 VerifyAccessToken에서 직접 확인하므로 ClientID 검사는 생략
+// 서명 키는 provider의 원격 키셋이 캐시하며, 모르는 kid가 오면 JWKS를 다시 가져와 키 교체를 처리
+func newAccessTokenVerifier(provider *oidc.Provider) *oidc.IDTokenVerifier {
+	return provider.Verifier(&oidc.Config{SkipClientIDCheck: true})
+}
+
+// VerifyAccessToken Bearer 액세스 토큰을 issuer JWKS로 로컬 검증 (iss, aud, exp, nbf, azp, 토큰 종류)
+// 만료된 토큰은 ErrTokenExpired를 감싸서 반환
+func (p *OIDCProvider) VerifyAccessToken(ctx context.Context, rawAccessToken string) (*oidc.IDToken, error) {
+	token, err := p.accessVerifier.Verify(ctx, rawAccessToken)
+	if err != nil {
+		var expiredErr *oidc.TokenExpiredError
+		if errors.As(err, &expiredErr) {
+			return nil, fmt.Errorf("%w at %s", ErrTokenExpired, expiredErr.Expiry.Format(time.RFC3339))
+		}
+		return nil, fmt.Errorf("failed to verify access token: %w", err)
+	}
+
+	cfg := config.Get()
+	if !slices.ContainsFunc(token.Audience, func(aud string) bool {
+		return slices.Contains(cfg.OIDC.AccessTokenAudiences, aud)
+	}) {
+		return nil, fmt.Errorf("access token audience %v is not accepted", token.Audience)
+	}
+
+	var claims accessTokenClaims
+	if err := token.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to parse access token claims: %v", err)
+	}
+	if claims.AuthorizedParty != "" && !slices.Contains(cfg.OIDC.AllowedAZP, claims.AuthorizedParty) {
+		return nil, fmt.Errorf("access token authorized party %q is not accepted", claims.AuthorizedParty)
+	}
+	if err := checkAccessTokenType(rawAccessToken, token, claims); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// checkAccessTokenType ID 토큰이 액세스 토큰으로 사용되지 않도록 토큰 종류 확인
+// 헤더 typ이 at+jwt(RFC 9068)이거나 Keycloak typ 클레임이 Bearer이면 액세스 토큰으로 인정하고,
+// 종류를 알 수 없으면 ID 토큰에만 있는 nonce, at_hash 클레임이 있을 때 거부
+func checkAccessTokenType(rawToken string, token *oidc.IDToken, claims accessTokenClaims) error {
+	switch strings.ToLower(jwtHeaderType(rawToken)) {
+	case "at+jwt", "application/at+jwt":
+		return nil
+	}
+
+	switch strings.ToLower(claims.Type) {
+	case "bearer":
+		return nil
+	case "":
+	default:
+		return fmt.Errorf("token of type %q is not an access token", claims.Type)
+	}
+
+	if token.Nonce != "" || token.AccessTokenHash != "" {
+		return fmt.Errorf("id token cannot be used as an access token")
+	}
+	return nil
+}
+
+// jwtHeaderType JWS 헤더의 typ 값 (서명 검증이 끝난 토큰에만 사용)
+func jwtHeaderType(rawToken string) string {
+	encoded, _, found := strings.Cut(rawToken, ".")
+	if !found {
+		return ""
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ""
+	}
+
+	var header struct {
+		Type string `json:"typ"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return ""
+	}
+	return header.Type
+}
+
+// FetchUserInfo userinfo 엔드포인트로 액세스 토큰 확인 (로컬 검증 실패 시 fallback)
+func (p *OIDCProvider) FetchUserInfo(ctx context.Context, rawAccessToken string) (*oidc.UserInfo, error) {
+	userInfo, err := p.provider.UserInfo(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: rawAccessToken}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call userinfo endpoint: %w", err)
+	}
+	return userInfo, nil
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// ClusterConfig 클러스터 레지스트리 항목 (콘솔 kubeconfig가 가리킬 수 있는 클러스터)
+type ClusterConfig struct {
+	Name          string   `json:"name"`                     // 클러스터 이름 (launch의 cluster 파라미터, cluster 라벨 값)
+	DisplayName   string   `json:"display_name,omitempty"`   // 화면 표시용 이름
+	Server        string   `json:"server"`                   // API 서버 URL
+	CAData        string   `json:"ca_data,omitempty"`        // CA 인증서 (base64 인코딩)
+	Audience      string   `json:"audience,omitempty"`       // 토큰 교환 대상 클라이언트 ID (비어 있으면 KUBERNETES_CLIENT_ID)
+	AllowedGroups []string `json:"allowed_groups,omitempty"` // 사용 가능한 그룹 (비어 있으면 모든 사용자)
+	Default       bool     `json:"default,omitempty"`        // cluster 파라미터가 없을 때 사용할 클러스터
+}
+
+// clustersFile CLUSTERS_FILE 파일 형식 (YAML 또는 JSON)
+type clustersFile struct {
+	Clusters []ClusterConfig `json:"clusters"`
+}
+
+// AllowsGroups 사용자 그룹으로 클러스터를 사용할 수 있는지 확인
+func (cl *ClusterConfig) AllowsGroups(groups []string) bool {
+	if len(cl.AllowedGroups) == 0 {
+		return true
+	}
+	for _, group := range groups {
+		if slices.Contains(cl.AllowedGroups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindCluster 이름으로 클러스터 조회 (이름이 비어 있으면 기본 클러스터 반환)
+func (c *Config) FindCluster(name string) (*ClusterConfig, bool) {
+	if name == "" {
+		cluster := c.DefaultCluster()
+		return cluster, cluster != nil
+	}
+	for i := range c.Kubernetes.Clusters {
+		if c.Kubernetes.Clusters[i].Name == name {
+			return &c.Kubernetes.Clusters[i], true
+		}
+	}
+	return nil, false
+}
+
+// DefaultCluster 기본 클러스터 반환 (default 표시가 없으면 첫 번째 항목)
+func (c *Config) DefaultCluster() *ClusterConfig {
+	for i := range c.Kubernetes.Clusters {
+		if c.Kubernetes.Clusters[i].Default {
+			return &c.Kubernetes.Clusters[i]
+		}
+	}
+	if len(c.Kubernetes.Clusters) > 0 {
+		return &c.Kubernetes.Clusters[0]
+	}
+	return nil
+}
+
+// loadClusters CLUSTERS_FILE에서 클러스터 레지스트리를 읽고, 없으면 단일 타겟 클러스터로 구성
+func loadClusters(config *Config) ([]ClusterConfig, error) {
+	if config.Kubernetes.ClustersFile == "" {
+		cluster := defaultCluster(config)
+		if err := validateClusterName(cluster.Name); err != nil {
+			return nil, fmt.Errorf("invalid DEFAULT_CLUSTER_NAME: %w", err)
+		}
+		return []ClusterConfig{cluster}, nil
+	}
+
+	data, err := os.ReadFile(config.Kubernetes.ClustersFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clusters file: %w", err)
+	}
+
+	var file clustersFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse clusters file: %w", err)
+	}
+
+	if len(file.Clusters) == 0 {
+		return nil, fmt.Errorf("clusters file %s has no clusters", config.Kubernetes.ClustersFile)
+	}
+
+	seen := make(map[string]bool)
+	defaults := 0
+	for i, cluster := range file.Clusters {
+		if cluster.Name == "" || cluster.Server == "" {
+			return nil, fmt.Errorf("cluster #%d in clusters file must have name and server", i+1)
+		}
+		if err := validateClusterName(cluster.Name); err != nil {
+			return nil, fmt.Errorf("cluster #%d in clusters file: %w", i+1, err)
+		}
+		if seen[cluster.Name] {
+			return nil, fmt.Errorf("duplicate cluster name in clusters file: %s", cluster.Name)
+		}
+		seen[cluster.Name] = true
+		if cluster.Default {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		return nil, fmt.Errorf("clusters file must have at most one default cluster")
+	}
+
+	return file.Clusters, nil
+}
+
+// validateClusterName 클러스터 이름은 리소스의 cluster 라벨과 라벨 셀렉터에 들어가므로 라벨 값 규칙 확인
+func validateClusterName(name string) error {
+	if errs := validation.IsValidLabelValue(name); len(errs) > 0 {
+		return fmt.Errorf("cluster name %q is not a valid label value: %s", name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// defaultCluster 기존 단일 클러스터 설정(CONSOLE_KUBECONFIG_CLUSTER 기준)으로 레지스트리 항목 생성
+func defaultCluster(config *Config) ClusterConfig {
+	cluster := ClusterConfig{
+		Name:     config.Kubernetes.DefaultClusterName,
+		Server:   config.Kubernetes.TargetServer,
+		CAData:   config.Kubernetes.TargetCAData,
+		Audience: config.OIDC.KubernetesClientID,
+		Default:  true,
+	}
+
+	if config.Kubernetes.KubeconfigCluster == ClusterLocal {
+		cluster.Server = config.Kubernetes.LocalServer
+		cluster.CAData = config.Kubernetes.LocalCAData
+	}
+	if cluster.Server == "" {
+		cluster.Server = "https://kubernetes.default.svc"
+	}
+
+	return cluster
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -31,11 +32,56 @@ type OIDCConfig struct {
 	IssuerURL          string `json:"issuer_url"`
 	RedirectURL        string `json:"redirect_url"`
 	KubernetesClientID string `json:"kubernetes_client_id"`
+
+	// 토큰 엔드포인트 클라이언트 인증 (client_secret_basic, client_secret_post, private_key_jwt)
+	ClientAuthMethod     string `json:"client_auth_method"`
+	ClientPrivateKeyFile string `json:"client_private_key_file"` // private_key_jwt 서명 키 (PEM, RSA 또는 EC)
+	ClientKeyID          string `json:"client_key_id"`           // private_key_jwt 서명 키의 kid 헤더
+
+	// RFC 8693 토큰 교환 설정
+	SubjectTokenType   string `json:"subject_token_type"`   // 교환할 토큰 종류
+	RequestedTokenType string `json:"requested_token_type"` // 요청할 토큰 종류
+	TokenExchangeParam string `json:"token_exchange_param"` // 대상 지정 파라미터 (audience, resource)
+
+	PostLogoutRedirectURL string `json:"post_logout_redirect_url"` // 로그아웃 후 돌아갈 프론트엔드 URL
+	PostLoginRedirectURL  string `json:"post_login_redirect_url"`  // 로그인(/auth/callback) 후 돌아갈 프론트엔드 URL
+
+	// 액세스 토큰 로컬 검증 (JWKS 서명 + iss/aud/exp/nbf/azp)
+	AccessTokenAudiences []string `json:"access_token_audiences"` // 허용할 aud 값 (하나라도 일치하면 통과)
+	AllowedAZP           []string `json:"allowed_azp"`            // 허용할 azp 값 (토큰에 azp가 있을 때만 확인)
+	UserInfoFallback     bool     `json:"userinfo_fallback"`      // 로컬 검증 실패 시 userinfo 엔드포인트로 재확인 (만료 토큰 제외)
+
+	// 사용자 그룹 클레임 설정
+	GroupsClaim        string `json:"groups_claim"`        // 그룹 클레임 경로 (점으로 중첩 경로 지정, 예: realm_access.roles)
+	UserInfoEnrichment bool   `json:"userinfo_enrichment"` // 검증된 토큰에 userinfo 클레임(그룹, 프로필)을 보강
+
+	// 그룹 → 네임스페이스/역할 매핑
+	GroupMappingsFile string             `json:"group_mappings_file"` // 매핑 파일 경로 (YAML/JSON, 비어 있으면 기본 매핑)
+	GroupMappings     GroupMappingConfig `json:"group_mappings"`
 }
 
+// 토큰 엔드포인트 클라이언트 인증 방식
+const (
+	ClientAuthBasic      = "client_secret_basic"
+	ClientAuthPost       = "client_secret_post"
+	ClientAuthPrivateKey = "private_key_jwt"
+)
+
+// 토큰 교환 대상 지정 파라미터
+const (
+	TokenExchangeAudience = "audience" // 대상 클라이언트 ID (Keycloak, Okta)
+	TokenExchangeResource = "resource" // 대상 리소스 URI (RFC 8707)
+)
+
+// TokenTypeAccessToken RFC 8693 액세스 토큰 종류 식별자
+const TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+
 // JWTConfig JWT 관련 설정
 type JWTConfig struct {
-	SecretKey string `json:"secret_key"`
+	SecretKey         string `json:"secret_key"`
+	SessionTTLSeconds int    `json:"session_ttl_seconds"` // portal-jwt 쿠키와 서버 측 로그인 세션의 수명
+	SessionStore      string `json:"session_store"`       // 로그인 세션 저장소 종류 (kubernetes, memory)
+	SessionNamespace  string `json:"session_namespace"`   // 로그인 세션 Secret을 저장할 포털 네임스페이스
 }
 
 // KubernetesConfig 쿠버네티스 관련 설정
@@ -43,11 +89,42 @@ type KubernetesConfig struct {
 	// 로컬 클러스터 설정 (A 클러스터 - 포털이 실행되는 곳)
 	Kubeconfig string `json:"kubeconfig"` // 개발 환경에서만 사용 (프로덕션에서는 InClusterConfig)
 
+	LocalServer string `json:"local_server"`  // 콘솔 Pod에서 A 클러스터에 접근할 API 서버 URL
+	LocalCAData string `json:"local_ca_data"` // A 클러스터 CA 인증서 (base64 인코딩, 비어 있으면 서비스 계정 CA 사용)
+
 	// 타겟 클러스터 설정 (B 클러스터 - 웹 콘솔에서 제어할 클러스터)
 	TargetServer string `json:"target_server"`  // B 클러스터 API 서버 URL (필수)
+	TargetToken  string `json:"-"`              // B 클러스터에 콘솔 리소스를 생성할 서비스 계정 토큰
 	TargetCAData string `json:"target_ca_data"` // B 클러스터 CA 인증서 (base64 인코딩)
+
+	// 콘솔 배치 설정 (local 또는 target)
+	ConsoleHostCluster string `json:"console_host_cluster"` // 콘솔 Pod가 실행될 클러스터 (기본값: local)
+	KubeconfigCluster  string `json:"kubeconfig_cluster"`   // 콘솔 kubeconfig가 가리킬 클러스터 (기본값: target)
+
+	// 클러스터 레지스트리 (사용자가 선택할 수 있는 kubeconfig 대상 클러스터 목록)
+	ClustersFile       string          `json:"clusters_file"`        // 레지스트리 파일 경로 (YAML/JSON, 비어 있으면 단일 클러스터)
+	DefaultClusterName string          `json:"default_cluster_name"` // 단일 클러스터 구성 시 클러스터 이름
+	Clusters           []ClusterConfig `json:"clusters"`
 }
 
+// 콘솔 배치 대상 클러스터
+const (
+	ClusterLocal  = "local"  // 포털이 실행되는 A 클러스터
+	ClusterTarget = "target" // TARGET_CLUSTER_SERVER로 지정된 B 클러스터
+)
+
+// 사용자 콘솔 제한 초과 시 동작 모드
+const (
+	LimitModeReject = "reject" // 에러 반환
+	LimitModeReuse  = "reuse"  // 가장 최근 콘솔 재사용
+)
+
+// 콘솔 실행 모드
+const (
+	LaunchModeNew   = "new"   // 항상 새 콘솔 생성
+	LaunchModeReuse = "reuse" // 준비된 기존 콘솔이 있으면 재사용
+)
+
 // ConsoleConfig 웹 콘솔 관련 설정
 type ConsoleConfig struct {
 	Namespace     string `json:"namespace"`
@@ -55,7 +132,28 @@ type ConsoleConfig struct {
 	ContainerPort int    `json:"container_port"`
 	ServicePort   int    `json:"service_port"`
 	TTLSeconds    int    `json:"ttl_seconds"`
+	MaxLifetime   int    `json:"max_lifetime_seconds"` // 연장 시 허용되는 최대 수명 (생성 시점 기준, 초)
 	BaseURL       string `json:"base_url"`
+	SessionStore  string `json:"session_store"` // 세션 저장소 종류 (memory, kubernetes)
+
+	// 콘솔 개수 제한 설정 (0이면 제한 없음)
+	MaxPerUser      int    `json:"max_per_user"`      // 사용자별 최대 동시 콘솔 수
+	MaxPerNamespace int    `json:"max_per_namespace"` // 네임스페이스 그룹별 최대 동시 콘솔 수
+	MaxTotal        int    `json:"max_total"`         // 클러스터 전체 최대 동시 콘솔 수
+	LimitMode       string `json:"limit_mode"`        // 사용자 제한 초과 시 동작 (reject, reuse)
+
+	LaunchMode string `json:"launch_mode"` // 기본 실행 모드 (new, reuse)
+
+	MinNamespaceRole string `json:"min_namespace_role"` // 콘솔/kubeconfig 네임스페이스 선택에 필요한 최소 역할 (비어 있으면 역할이 있으면 허용)
+
+	// 유휴 세션 정리 설정
+	IdleTimeoutMinutes int    `json:"idle_timeout_minutes"` // 터미널 활동이 없을 때 정리까지의 시간 (0이면 비활성화)
+	HeartbeatURL       string `json:"heartbeat_url"`        // 콘솔 Pod에서 접근 가능한 백엔드 URL (비어 있으면 유휴 정리 비활성화)
+	HeartbeatSecret    string `json:"-"`                    // 활동 보고 토큰 서명 키 (비어 있으면 JWT_SECRET_KEY에서 파생)
+
+	// 콘솔 쿠버네티스 토큰 자동 갱신 설정
+	TokenRefreshIntervalSeconds int `json:"token_refresh_interval_seconds"` // 갱신 대상 확인 주기 (0이면 비활성화)
+	TokenRefreshBeforeSeconds   int `json:"token_refresh_before_seconds"`   // 만료 몇 초 전부터 갱신할지
 }
 
 // LoggingConfig 로깅 관련 설정
@@ -79,14 +177,46 @@ func Load() (*Config, error) {
 			IssuerURL:          getEnvWithDefault("OIDC_ISSUER_URL", ""),
 			RedirectURL:        getEnvWithDefault("OIDC_REDIRECT_URL", ""),
 			KubernetesClientID: getEnvWithDefault("KUBERNETES_CLIENT_ID", ""),
+
+			ClientAuthMethod:     getEnvWithDefault("OIDC_CLIENT_AUTH_METHOD", ClientAuthBasic),
+			ClientPrivateKeyFile: getEnvWithDefault("OIDC_CLIENT_PRIVATE_KEY_FILE", ""),
+			ClientKeyID:          getEnvWithDefault("OIDC_CLIENT_KEY_ID", ""),
+
+			SubjectTokenType:   getEnvWithDefault("OIDC_SUBJECT_TOKEN_TYPE", TokenTypeAccessToken),
+			RequestedTokenType: getEnvWithDefault("OIDC_REQUESTED_TOKEN_TYPE", TokenTypeAccessToken),
+			TokenExchangeParam: getEnvWithDefault("OIDC_TOKEN_EXCHANGE_PARAM", TokenExchangeAudience),
+
+			PostLogoutRedirectURL: getEnvWithDefault("OIDC_POST_LOGOUT_REDIRECT_URL", "https://front.miribit.cloud"),
+			PostLoginRedirectURL:  getEnvWithDefault("OIDC_POST_LOGIN_REDIRECT_URL", "https://front.miribit.cloud"),
+
+			AccessTokenAudiences: parseStringSlice(getEnvWithDefault("OIDC_ACCESS_TOKEN_AUDIENCE", getEnvWithDefault("OIDC_CLIENT_ID", ""))),
+			AllowedAZP:           parseStringSlice(getEnvWithDefault("OIDC_ALLOWED_AZP", getEnvWithDefault("OIDC_CLIENT_ID", ""))),
+			UserInfoFallback:     getEnvAsBoolWithDefault("OIDC_USERINFO_FALLBACK", false),
+
+			GroupsClaim:        getEnvWithDefault("OIDC_GROUPS_CLAIM", "groups"),
+			UserInfoEnrichment: getEnvAsBoolWithDefault("OIDC_USERINFO_ENRICHMENT", false),
+
+			GroupMappingsFile: getEnvWithDefault("GROUP_MAPPINGS_FILE", ""),
 		},
 		JWT: JWTConfig{
-			SecretKey: getEnvWithDefault("JWT_SECRET_KEY", ""),
+			SecretKey:         getEnvWithDefault("JWT_SECRET_KEY", ""),
+			SessionTTLSeconds: getEnvAsIntWithDefault("JWT_SESSION_TTL_SECONDS", 28800), // 8시간
+			SessionStore:      getEnvWithDefault("LOGIN_SESSION_STORE", "kubernetes"),
+			SessionNamespace:  getEnvWithDefault("LOGIN_SESSION_NAMESPACE", "user-portal"),
 		},
 		Kubernetes: KubernetesConfig{
 			Kubeconfig:   getEnvWithDefault("KUBECONFIG", ""),
+			LocalServer:  getEnvWithDefault("LOCAL_CLUSTER_SERVER", "https://kubernetes.default.svc"),
+			LocalCAData:  getEnvWithDefault("LOCAL_CLUSTER_CA_CERT_DATA", ""),
 			TargetServer: getEnvWithDefault("TARGET_CLUSTER_SERVER", ""),
+			TargetToken:  getEnvWithDefault("TARGET_CLUSTER_TOKEN", ""),
 			TargetCAData: getEnvWithDefault("TARGET_CLUSTER_CA_CERT_DATA", ""),
+
+			ConsoleHostCluster: getEnvWithDefault("CONSOLE_HOST_CLUSTER", ClusterLocal),
+			KubeconfigCluster:  getEnvWithDefault("CONSOLE_KUBECONFIG_CLUSTER", ClusterTarget),
+
+			ClustersFile:       getEnvWithDefault("CLUSTERS_FILE", ""),
+			DefaultClusterName: getEnvWithDefault("DEFAULT_CLUSTER_NAME", "default"),
 		},
 		Console: ConsoleConfig{
 			Namespace:     getEnvWithDefault("CONSOLE_NAMESPACE", "default"),
@@ -94,7 +224,25 @@ func Load() (*Config, error) {
 			ContainerPort: getEnvAsIntWithDefault("CONSOLE_CONTAINER_PORT", 8080),
 			ServicePort:   getEnvAsIntWithDefault("CONSOLE_SERVICE_PORT", 80),
 			TTLSeconds:    getEnvAsIntWithDefault("CONSOLE_TTL_SECONDS", 3600),
+			MaxLifetime:   getEnvAsIntWithDefault("CONSOLE_MAX_LIFETIME_SECONDS", 28800),
 			BaseURL:       getEnvWithDefault("WEB_CONSOLE_BASE_URL", "console.basphere.dev"),
+			SessionStore:  getEnvWithDefault("CONSOLE_SESSION_STORE", "kubernetes"),
+
+			MaxPerUser:      getEnvAsIntWithDefault("CONSOLE_MAX_PER_USER", 0),
+			MaxPerNamespace: getEnvAsIntWithDefault("CONSOLE_MAX_PER_NAMESPACE", 0),
+			MaxTotal:        getEnvAsIntWithDefault("CONSOLE_MAX_TOTAL", 0),
+			LimitMode:       getEnvWithDefault("CONSOLE_LIMIT_MODE", LimitModeReject),
+
+			LaunchMode: getEnvWithDefault("CONSOLE_LAUNCH_MODE", LaunchModeNew),
+
+			MinNamespaceRole: getEnvWithDefault("CONSOLE_MIN_NAMESPACE_ROLE", ""),
+
+			IdleTimeoutMinutes: getEnvAsIntWithDefault("CONSOLE_IDLE_TIMEOUT_MINUTES", 30),
+			HeartbeatURL:       getEnvWithDefault("CONSOLE_HEARTBEAT_URL", ""),
+			HeartbeatSecret:    getEnvWithDefault("CONSOLE_HEARTBEAT_SECRET", ""),
+
+			TokenRefreshIntervalSeconds: getEnvAsIntWithDefault("CONSOLE_TOKEN_REFRESH_INTERVAL_SECONDS", 60),
+			TokenRefreshBeforeSeconds:   getEnvAsIntWithDefault("CONSOLE_TOKEN_REFRESH_BEFORE_SECONDS", 120),
 		},
 		Logging: LoggingConfig{
 			Level: strings.ToUpper(getEnvWithDefault("LOG_LEVEL", "INFO")),
@@ -106,6 +254,23 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("config validation failed: %w", err)
 	}
 
+	// 클러스터 레지스트리 로드
+	clusters, err := loadClusters(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cluster registry: %w", err)
+	}
+	config.Kubernetes.Clusters = clusters
+
+	// 그룹 → 네임스페이스/역할 매핑 로드
+	groupMappings, err := loadGroupMappings(config.OIDC.GroupMappingsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load group mappings: %w", err)
+	}
+	config.OIDC.GroupMappings = groupMappings
+	if role := config.Console.MinNamespaceRole; role != "" && !slices.Contains(groupMappings.Roles, role) {
+		return nil, fmt.Errorf("CONSOLE_MIN_NAMESPACE_ROLE must be one of %v (got %q)", groupMappings.Roles, role)
+	}
+
 	globalConfig = config
 	return config, nil
 }
@@ -121,11 +286,25 @@ func Get() *Config {
 // validateConfig 필수 설정 검증
 func validateConfig(config *Config) error {
 	required := map[string]string{
-		"OIDC_CLIENT_ID":     config.OIDC.ClientID,
-		"OIDC_CLIENT_SECRET": config.OIDC.ClientSecret,
-		"OIDC_ISSUER_URL":    config.OIDC.IssuerURL,
-		"OIDC_REDIRECT_URL":  config.OIDC.RedirectURL,
-		"JWT_SECRET_KEY":     config.JWT.SecretKey,
+		"OIDC_CLIENT_ID":    config.OIDC.ClientID,
+		"OIDC_ISSUER_URL":   config.OIDC.IssuerURL,
+		"OIDC_REDIRECT_URL": config.OIDC.RedirectURL,
+		"JWT_SECRET_KEY":    config.JWT.SecretKey,
+	}
+
+	// 클라이언트 인증 방식에 따라 시크릿 또는 서명 키 필요
+	switch config.OIDC.ClientAuthMethod {
+	case ClientAuthBasic, ClientAuthPost:
+		required["OIDC_CLIENT_SECRET"] = config.OIDC.ClientSecret
+	case ClientAuthPrivateKey:
+		required["OIDC_CLIENT_PRIVATE_KEY_FILE"] = config.OIDC.ClientPrivateKeyFile
+	default:
+		return fmt.Errorf("OIDC_CLIENT_AUTH_METHOD must be one of: %s, %s, %s (got %q)",
+			ClientAuthBasic, ClientAuthPost, ClientAuthPrivateKey, config.OIDC.ClientAuthMethod)
+	}
+	if config.OIDC.TokenExchangeParam != TokenExchangeAudience && config.OIDC.TokenExchangeParam != TokenExchangeResource {
+		return fmt.Errorf("OIDC_TOKEN_EXCHANGE_PARAM must be one of: %s, %s (got %q)",
+			TokenExchangeAudience, TokenExchangeResource, config.OIDC.TokenExchangeParam)
 	}
 
 	var missing []string
@@ -139,6 +318,37 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("missing required environment variables: %s", strings.Join(missing, ", "))
 	}
 
+	// 콘솔 배치 클러스터 검증
+	placements := map[string]string{
+		"CONSOLE_HOST_CLUSTER":       config.Kubernetes.ConsoleHostCluster,
+		"CONSOLE_KUBECONFIG_CLUSTER": config.Kubernetes.KubeconfigCluster,
+	}
+	for key, value := range placements {
+		if value != ClusterLocal && value != ClusterTarget {
+			return fmt.Errorf("%s must be one of: %s, %s (got %q)", key, ClusterLocal, ClusterTarget, value)
+		}
+	}
+	if config.Kubernetes.ConsoleHostCluster == ClusterTarget && config.Kubernetes.TargetServer == "" {
+		return fmt.Errorf("TARGET_CLUSTER_SERVER is required when CONSOLE_HOST_CLUSTER=%s", ClusterTarget)
+	}
+
+	// 콘솔 수명 검증 (연장 상한은 기본 TTL 이상이어야 함)
+	if config.Console.TTLSeconds <= 0 {
+		return fmt.Errorf("CONSOLE_TTL_SECONDS must be positive (got %d)", config.Console.TTLSeconds)
+	}
+	if config.Console.MaxLifetime < config.Console.TTLSeconds {
+		return fmt.Errorf("CONSOLE_MAX_LIFETIME_SECONDS must be >= CONSOLE_TTL_SECONDS (got %d < %d)",
+			config.Console.MaxLifetime, config.Console.TTLSeconds)
+	}
+	if config.Console.LimitMode != LimitModeReject && config.Console.LimitMode != LimitModeReuse {
+		return fmt.Errorf("CONSOLE_LIMIT_MODE must be one of: %s, %s (got %q)",
+			LimitModeReject, LimitModeReuse, config.Console.LimitMode)
+	}
+	if config.Console.LaunchMode != LaunchModeNew && config.Console.LaunchMode != LaunchModeReuse {
+		return fmt.Errorf("CONSOLE_LAUNCH_MODE must be one of: %s, %s (got %q)",
+			LaunchModeNew, LaunchModeReuse, config.Console.LaunchMode)
+	}
+
 	return nil
 }
 
@@ -159,6 +369,15 @@ func getEnvAsIntWithDefault(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsBoolWithDefault(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 func parseStringSlice(value string) []string {
 	if value == "" {
 		return []string{}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// validTestConfig validateConfig를 통과하는 최소 설정
+func validTestConfig() *Config {
+	return &Config{
+		OIDC: OIDCConfig{
+			ClientID:           "portal-app",
+			ClientSecret:       "secret",
+			IssuerURL:          "https://idp.example.com/realms/test",
+			RedirectURL:        "https://portal.example.com/auth/callback",
+			ClientAuthMethod:   ClientAuthBasic,
+			TokenExchangeParam: TokenExchangeAudience,
+		},
+		JWT: JWTConfig{SecretKey: "test-secret"},
+		Kubernetes: KubernetesConfig{
+			ConsoleHostCluster: ClusterLocal,
+			KubeconfigCluster:  ClusterTarget,
+		},
+		Console: ConsoleConfig{
+			TTLSeconds:  3600,
+			MaxLifetime: 28800,
+			LimitMode:   LimitModeReject,
+			LaunchMode:  LaunchModeNew,
+		},
+	}
+}
+
+func TestValidateConfigConsole(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr string
+	}{
+		{name: "defaults are valid", mutate: func(*Config) {}},
+		{name: "max lifetime equal to ttl", mutate: func(c *Config) { c.Console.MaxLifetime = c.Console.TTLSeconds }},
+		{name: "max lifetime below ttl", mutate: func(c *Config) { c.Console.MaxLifetime = 60 }, wantErr: "CONSOLE_MAX_LIFETIME_SECONDS"},
+		{name: "zero max lifetime", mutate: func(c *Config) { c.Console.MaxLifetime = 0 }, wantErr: "CONSOLE_MAX_LIFETIME_SECONDS"},
+		{name: "negative max lifetime", mutate: func(c *Config) { c.Console.MaxLifetime = -1 }, wantErr: "CONSOLE_MAX_LIFETIME_SECONDS"},
+		{name: "reuse limit mode", mutate: func(c *Config) { c.Console.LimitMode = LimitModeReuse }},
+		{name: "unknown limit mode", mutate: func(c *Config) { c.Console.LimitMode = "queue" }, wantErr: "CONSOLE_LIMIT_MODE"},
+		{name: "reuse launch mode", mutate: func(c *Config) { c.Console.LaunchMode = LaunchModeReuse }},
+		{name: "unknown launch mode", mutate: func(c *Config) { c.Console.LaunchMode = "attach" }, wantErr: "CONSOLE_LAUNCH_MODE"},
+		{name: "zero ttl", mutate: func(c *Config) { c.Console.TTLSeconds = 0 }, wantErr: "CONSOLE_TTL_SECONDS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validTestConfig()
+			tt.mutate(cfg)
+
+			err := validateConfig(cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateConfig() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("validateConfig() error = %v, want error mentioning %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// 그룹 패턴 종류
+const (
+	PatternGlob  = "glob"  // * 는 경로 한 단계(/ 제외), ** 는 여러 단계와 일치하며 각각 캡처 그룹이 됨
+	PatternRegex = "regex" // 정규식 (전체 일치, 이름 있는 캡처 사용 가능)
+)
+
+// GroupMappingRule 그룹 패턴을 네임스페이스와 포털 역할로 매핑하는 규칙
+type GroupMappingRule struct {
+	Match        string `json:"match"`                   // 그룹 패턴
+	Type         string `json:"type,omitempty"`          // 패턴 종류 (glob, regex, 기본값: glob)
+	Namespace    string `json:"namespace,omitempty"`     // 네임스페이스 템플릿 ($1, ${name})
+	Role         string `json:"role,omitempty"`          // 포털 역할 (roles 중 하나)
+	ClusterAdmin bool   `json:"cluster_admin,omitempty"` // 모든 네임스페이스에 최고 역할 부여 (기존 cluster-admins)
+}
+
+// GroupMappingConfig 그룹 → 네임스페이스/역할 매핑 설정
+type GroupMappingConfig struct {
+	Roles []string           `json:"roles"` // 포털 역할 (권한이 높은 순서)
+	Rules []GroupMappingRule `json:"rules"`
+}
+
+// Pattern 규칙의 그룹 패턴을 전체 일치 정규식으로 변환
+func (r *GroupMappingRule) Pattern() (*regexp.Regexp, error) {
+	if r.Type == PatternRegex {
+		return regexp.Compile("^(?:" + r.Match + ")$")
+	}
+
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for i := 0; i < len(r.Match); i++ {
+		switch {
+		case strings.HasPrefix(r.Match[i:], "**"):
+			pattern.WriteString("(.*)")
+			i++
+		case r.Match[i] == '*':
+			pattern.WriteString("([^/]*)")
+		case r.Match[i] == '?':
+			pattern.WriteString("([^/])")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(r.Match[i])))
+		}
+	}
+	pattern.WriteString("$")
+	return regexp.Compile(pattern.String())
+}
+
+// defaultGroupMappings 기존 /최상위그룹/서비스명/{adm|dev|view} 규칙과 cluster-admins 그룹
+func defaultGroupMappings() GroupMappingConfig {
+	return GroupMappingConfig{
+		Roles: []string{"admin", "developer", "viewer"},
+		Rules: []GroupMappingRule{
+			{Match: "cluster-admins", ClusterAdmin: true},
+			{Match: "/*/*/adm", Namespace: "$2", Role: "admin"},
+			{Match: "/*/*/dev", Namespace: "$2", Role: "developer"},
+			{Match: "/*/*/view", Namespace: "$2", Role: "viewer"},
+		},
+	}
+}
+
+// loadGroupMappings GROUP_MAPPINGS_FILE에서 매핑을 읽고, 없으면 기본 매핑 사용
+func loadGroupMappings(path string) (GroupMappingConfig, error) {
+	if path == "" {
+		return defaultGroupMappings(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return GroupMappingConfig{}, fmt.Errorf("failed to read group mappings file: %w", err)
+	}
+
+	var mappings GroupMappingConfig
+	if err := yaml.Unmarshal(data, &mappings); err != nil {
+		return GroupMappingConfig{}, fmt.Errorf("failed to parse group mappings file: %w", err)
+	}
+
+	if len(mappings.Roles) == 0 {
+		return GroupMappingConfig{}, fmt.Errorf("group mappings file %s must define at least one role", path)
+	}
+
+	for i := range mappings.Rules {
+		rule := &mappings.Rules[i]
+		if rule.Match == "" {
+			return GroupMappingConfig{}, fmt.Errorf("group mapping rule #%d must have match", i+1)
+		}
+		if rule.Type != "" && rule.Type != PatternGlob && rule.Type != PatternRegex {
+			return GroupMappingConfig{}, fmt.Errorf("group mapping rule #%d has unknown type %q", i+1, rule.Type)
+		}
+		if _, err := rule.Pattern(); err != nil {
+			return GroupMappingConfig{}, fmt.Errorf("group mapping rule #%d has invalid pattern: %w", i+1, err)
+		}
+		if rule.ClusterAdmin {
+			continue
+		}
+		if rule.Namespace == "" || !slices.Contains(mappings.Roles, rule.Role) {
+			return GroupMappingConfig{}, fmt.Errorf("group mapping rule #%d must have namespace and a role from %v", i+1, mappings.Roles)
+		}
+	}
+
+	return mappings, nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupMappingRulePattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     GroupMappingRule
+		group    string
+		want     bool
+		captures []string
+	}{
+		{name: "glob star matches one path segment", rule: GroupMappingRule{Match: "/*/*/adm"}, group: "/org/blue/adm", want: true, captures: []string{"org", "blue"}},
+		{name: "glob star does not cross slash", rule: GroupMappingRule{Match: "/*/*/adm"}, group: "/org/team/blue/adm", want: false},
+		{name: "glob double star crosses slashes", rule: GroupMappingRule{Match: "/**/adm"}, group: "/org/team/blue/adm", want: true, captures: []string{"org/team/blue"}},
+		{name: "glob question mark matches one character", rule: GroupMappingRule{Match: "team-?"}, group: "team-a", want: true, captures: []string{"a"}},
+		{name: "glob question mark does not match slash", rule: GroupMappingRule{Match: "team?a"}, group: "team/a", want: false},
+		{name: "glob is anchored", rule: GroupMappingRule{Match: "/*/*/adm"}, group: "/org/blue/adm-old", want: false},
+		{name: "glob quotes regex metacharacters", rule: GroupMappingRule{Match: "ops.admins"}, group: "opsXadmins", want: false},
+		{name: "glob literal match", rule: GroupMappingRule{Match: "cluster-admins"}, group: "cluster-admins", want: true, captures: []string{}},
+		{name: "regex named capture", rule: GroupMappingRule{Type: PatternRegex, Match: `/org/(?P<svc>[a-z]+)/dev`}, group: "/org/blue/dev", want: true, captures: []string{"blue"}},
+		{name: "regex is anchored", rule: GroupMappingRule{Type: PatternRegex, Match: `[a-z]+-admins`}, group: "x/ops-admins", want: false},
+		{name: "regex alternation is anchored as a whole", rule: GroupMappingRule{Type: PatternRegex, Match: `a|b`}, group: "ab", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := tt.rule.Pattern()
+			if err != nil {
+				t.Fatalf("Pattern() error = %v", err)
+			}
+
+			match := pattern.FindStringSubmatch(tt.group)
+			if got := match != nil; got != tt.want {
+				t.Fatalf("Pattern(%q) matching %q = %v, want %v (regexp %s)", tt.rule.Match, tt.group, got, tt.want, pattern)
+			}
+			if tt.want && !reflect.DeepEqual(match[1:], tt.captures) {
+				t.Errorf("Pattern(%q) captures for %q = %q, want %q", tt.rule.Match, tt.group, match[1:], tt.captures)
+			}
+		})
+	}
+}
+
+func TestGroupMappingRulePatternInvalidRegex(t *testing.T) {
+	rule := GroupMappingRule{Type: PatternRegex, Match: "(unclosed"}
+	if _, err := rule.Pattern(); err == nil {
+		t.Fatal("Pattern() error = nil, want error for invalid regex")
+	}
+}
@@ -1,20 +1,36 @@
 package handlers
 
 import (
+	"github.com/gin-gonic/gin"
+
 	"portal-backend/internal/auth"
 	"portal-backend/internal/kubernetes"
+	"portal-backend/internal/middleware"
+	"portal-backend/internal/utils"
 )
 
 // AuthHandler 인증 핸들러
 type AuthHandler struct {
 	oidcProvider *auth.OIDCProvider
+	sessions     *auth.SessionManager // 인가 코드 로그인(BFF) 세션
 	k8sClient    *kubernetes.Client
 }
 
 // NewAuthHandler 새로운 인증 핸들러 생성
-func NewAuthHandler(oidcProvider *auth.OIDCProvider, k8sClient *kubernetes.Client) (*AuthHandler, error) {
+func NewAuthHandler(oidcProvider *auth.OIDCProvider, sessions *auth.SessionManager, k8sClient *kubernetes.Client) (*AuthHandler, error) {
 	return &AuthHandler{
 		oidcProvider: oidcProvider,
+		sessions:     sessions,
 		k8sClient:    k8sClient,
 	}, nil
 }
+
+// requireIdentity AuthMiddleware가 저장한 사용자 정보 조회 (없으면 401 응답 후 false 반환)
+func requireIdentity(c *gin.Context) (*auth.Identity, bool) {
+	identity, ok := middleware.GetIdentity(c)
+	if !ok {
+		utils.Response.Unauthorized(c, "Authentication is required")
+		return nil, false
+	}
+	return identity, true
+}
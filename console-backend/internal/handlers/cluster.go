@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"portal-backend/internal/config"
+	"portal-backend/internal/models"
+	"portal-backend/internal/utils"
+)
+
+// resolveCluster cluster 파라미터로 레지스트리 클러스터를 찾고 사용자 그룹으로 접근 권한 확인
+// 이름이 비어 있으면 기본 클러스터 사용
+func resolveCluster(name string, groups []string) (*config.ClusterConfig, *models.APIError) {
+	cluster, exists := config.Get().FindCluster(name)
+	if !exists {
+		return nil, models.ErrClusterNotFound.WithDetails("Cluster: " + name)
+	}
+
+	if !cluster.AllowsGroups(groups) {
+		return nil, models.ErrResourceAccessDenied.WithDetails(fmt.Sprintf("Cluster %s is not available to your groups", cluster.Name))
+	}
+
+	return cluster, nil
+}
+
+// availableClusters 사용자 그룹으로 사용할 수 있는 레지스트리 클러스터 목록
+func availableClusters(groups []string) []models.ClusterInfo {
+	cfg := config.Get()
+	defaultCluster := cfg.DefaultCluster()
+
+	clusters := make([]models.ClusterInfo, 0, len(cfg.Kubernetes.Clusters))
+	for _, cluster := range cfg.Kubernetes.Clusters {
+		if !cluster.AllowsGroups(groups) {
+			continue
+		}
+
+		displayName := cluster.DisplayName
+		if displayName == "" {
+			displayName = cluster.Name
+		}
+		clusters = append(clusters, models.ClusterInfo{
+			Name:        cluster.Name,
+			DisplayName: displayName,
+			Server:      cluster.Server,
+			Default:     defaultCluster != nil && cluster.Name == defaultCluster.Name,
+		})
+	}
+
+	return clusters
+}
+
+// HandleListClusters 호출자가 사용할 수 있는 클러스터 목록 조회
+func (h *ConsoleHandler) HandleListClusters(c *gin.Context) {
+	// AuthMiddleware가 검증한 사용자 정보
+	identity, ok := requireIdentity(c)
+	if !ok {
+		return
+	}
+
+	clusters := availableClusters(identity.Groups)
+	utils.Response.Success(c, models.ListClustersResponse{
+		Clusters: clusters,
+		Count:    len(clusters),
+	})
+}
@@ -2,12 +2,11 @@ package handlers
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
-	"os"
-	"strings"
+	"net/url"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -20,89 +19,155 @@ import (
 	"portal-backend/internal/utils"
 )
 
+// 콘솔 실행 모드
+const (
+	LaunchModeNew   = config.LaunchModeNew   // 항상 새 콘솔 생성
+	LaunchModeReuse = config.LaunchModeReuse // 준비된 기존 콘솔이 있으면 재사용
+)
+
+// consoleEventsKeepAlive SSE 연결 유지를 위한 주석 전송 간격
+const consoleEventsKeepAlive = 15 * time.Second
+
 // ConsoleHandler 웹 콘솔 핸들러
 type ConsoleHandler struct {
 	k8sClient   *kubernetes.Client
 	authHandler *AuthHandler
-	// 생성된 리소스 추적 (실제로는 Redis나 DB 사용 권장)
-	resources map[string]*kubernetes.ConsoleResource
+	// 생성된 리소스 추적 (메모리 또는 클러스터 라벨 기반 저장소)
+	store kubernetes.SessionStore
+	// 사용자별 개수 확인과 생성 직렬화
+	launchLocks *launchLocks
+	// 네임스페이스/전체 개수 확인과 생성 직렬화
+	capacityLocks *launchLocks
 }
 
 // NewConsoleHandler 새로운 콘솔 핸들러 생성
-func NewConsoleHandler(k8sClient *kubernetes.Client, authHandler *AuthHandler) *ConsoleHandler {
+func NewConsoleHandler(k8sClient *kubernetes.Client, authHandler *AuthHandler, store kubernetes.SessionStore) *ConsoleHandler {
 	handler := &ConsoleHandler{
 		k8sClient:   k8sClient,
 		authHandler: authHandler,
-		resources:   make(map[string]*kubernetes.ConsoleResource),
+		store:       store,
+		launchLocks: newLaunchLocks(),
+
+		capacityLocks: newLaunchLocks(),
 	}
 
+	// 클러스터에 남아 있는 콘솔 세션 재구성
+	handler.reconcileSessions()
+
 	// 백그라운드에서 주기적으로 만료된 리소스 정리
 	go handler.startCleanupRoutine()
 
+	// 실행 중인 콘솔의 쿠버네티스 토큰을 만료 전에 갱신
+	go handler.startTokenRefreshRoutine()
+
 	return handler
 }
 
 // HandleLaunchConsole 웹 콘솔 Pod 생성
 func (h *ConsoleHandler) HandleLaunchConsole(c *gin.Context) {
-	ctx := context.Background()
-
-	// Authorization 헤더에서 Bearer 토큰(OIDC Access Token) 추출
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-		utils.Response.Unauthorized(c, "Authorization header with Bearer token is required")
+	// AuthMiddleware가 검증한 사용자 정보
+	identity, ok := requireIdentity(c)
+	if !ok {
 		return
 	}
+	userID := identity.UserID()
 
-	oidcAccessToken := strings.TrimPrefix(authHeader, "Bearer ")
-
-	// OIDC Access Token 검증 (userinfo 엔드포인트 사용)
-	userInfo, err := h.validateOIDCAccessToken(ctx, oidcAccessToken)
-	if err != nil {
-		logger.WarnWithContext(c.Request.Context(), "Failed to verify OIDC token", map[string]any{
-			"error": err.Error(),
-		})
-		utils.Response.Unauthorized(c, fmt.Sprintf("Invalid OIDC token: %s", err.Error()))
+	// 실행 모드 확인 (쿼리 파라미터가 없으면 설정값 사용)
+	cfg := config.Get()
+	mode := c.DefaultQuery("mode", cfg.Console.LaunchMode)
+	if mode != LaunchModeNew && mode != LaunchModeReuse {
+		utils.Response.ValidationError(c, "mode", "Mode must be one of: new, reuse")
 		return
 	}
 
-	userID := userInfo.PreferredUsername
-	if userID == "" {
-		userID = userInfo.Subject
-	}
+	// 사용자 그룹 정보 확인 및 기본 네임스페이스 결정
+	userGroups := identity.UserGroups()
 
-	// OIDC Access Token을 Kubernetes용 토큰으로 교환
-	exchangeResp, err := auth.ExchangeTokenForKubernetes(oidcAccessToken)
-	if err != nil {
-		logger.ErrorWithContext(c.Request.Context(), "Failed to exchange token for kubernetes", err, map[string]any{
+	// kubeconfig가 가리킬 클러스터 선택 (파라미터가 없으면 기본 클러스터)
+	cluster, clusterErr := resolveCluster(c.Query("cluster"), userGroups.Groups)
+	if clusterErr != nil {
+		logger.WarnWithContext(c.Request.Context(), "Cluster selection rejected", map[string]any{
 			"user_id": userID,
+			"cluster": c.Query("cluster"),
+			"error":   clusterErr.Error(),
 		})
-		utils.Response.InternalError(c, fmt.Errorf("failed to get kubernetes token: %w", err))
+		utils.Response.Error(c, clusterErr)
 		return
 	}
 
-	newK8sAccessToken := exchangeResp.AccessToken
-
-	// 사용자 그룹 정보 확인 및 기본 네임스페이스 결정
-	// ID 토큰에서 그룹 정보를 추출하기 위해 raw ID token을 사용
-	rawIDToken := oidcAccessToken // 실제로는 ID token이어야 하지만, Access token에서 userinfo로 그룹 정보를 가져올 수 있음
-	userGroups, err := auth.ExtractUserGroups(rawIDToken)
-	if err != nil {
-		logger.WarnWithContext(c.Request.Context(), "Failed to extract user groups", map[string]any{
-			"user_id": userID,
-			"error":   err.Error(),
+	// 콘솔 기본 네임스페이스 선택 (파라미터가 없으면 그룹 매핑 규칙으로 결정)
+	requestedNamespace := c.Query("namespace")
+	defaultNamespace, namespaceErr := resolveNamespace(requestedNamespace, userGroups)
+	if namespaceErr != nil {
+		logger.WarnWithContext(c.Request.Context(), "Namespace selection rejected", map[string]any{
+			"user_id":   userID,
+			"namespace": requestedNamespace,
+			"error":     namespaceErr.Error(),
 		})
-		userGroups = &auth.UserGroups{UserID: userID, Username: userID}
+		utils.Response.Error(c, namespaceErr)
+		return
+	}
+
+	// reuse 모드에서는 같은 클러스터에 준비된 기존 콘솔이 있으면 바로 반환
+	// 네임스페이스를 지정한 경우 그 네임스페이스로 실행된 콘솔만 재사용
+	if mode == LaunchModeReuse {
+		existing, err := h.k8sClient.FindReadyConsole(userID, cluster.Name, requestedNamespace, cfg.Console.Namespace)
+		if err != nil {
+			logger.WarnWithContext(c.Request.Context(), "Failed to look up existing console", map[string]any{
+				"user_id": userID,
+				"error":   err.Error(),
+			})
+		}
+		if existing != nil && h.reuseConsole(c, userID, []*kubernetes.ConsoleResource{existing}) {
+			return
+		}
 	}
 
-	defaultNamespace := userGroups.DetermineDefaultNamespace()
 	logger.InfoWithContext(c.Request.Context(), "Determined default namespace for user", map[string]any{
 		"user_id":           userID,
 		"groups":            userGroups.Groups,
+		"cluster":           cluster.Name,
 		"default_namespace": defaultNamespace,
+		"requested":         requestedNamespace != "",
 	})
 
-	// 웹 콘솔 리소스 생성 (기본 네임스페이스 전달)
-	resource, err := h.k8sClient.CreateConsoleResources(userID, newK8sAccessToken, "", defaultNamespace)
+	// 개수 확인부터 저장까지 같은 사용자의 동시 실행 요청을 직렬화
+	unlock := h.launchLocks.lock(userID)
+	defer unlock()
+
+	// 네임스페이스/전체 제한이 있으면 해당 범위의 동시 실행 요청도 직렬화 (사용자 잠금 다음에 획득)
+	if key := capacityLockKey(defaultNamespace); key != "" {
+		unlockCapacity := h.capacityLocks.lock(key)
+		defer unlockCapacity()
+	}
+
+	// 콘솔 개수 제한 확인 (reuse 모드에서는 실패하지 않은 기존 콘솔 반환)
+	candidates, quotaErr := h.checkConsoleQuota(userID, cluster.Name, defaultNamespace)
+	if quotaErr != nil {
+		if h.reuseConsole(c, userID, candidates) {
+			return
+		}
+		logger.WarnWithContext(c.Request.Context(), "Console quota exceeded", map[string]any{
+			"user_id":   userID,
+			"namespace": defaultNamespace,
+			"error":     quotaErr.Error(),
+		})
+		utils.Response.Error(c, quotaErr)
+		return
+	}
+
+	// OIDC Access Token을 Kubernetes용 토큰으로 교환
+	exchangeResp, err := auth.ExchangeTokenForKubernetes(identity.Token, cluster.Audience)
+	if err != nil {
+		logger.ErrorWithContext(c.Request.Context(), "Failed to exchange token for kubernetes", err, map[string]any{
+			"user_id": userID,
+		})
+		utils.Response.InternalError(c, fmt.Errorf("failed to get kubernetes token: %w", err))
+		return
+	}
+
+	// 웹 콘솔 리소스 생성 (기본 네임스페이스 전달, refresh token은 세션 Secret에 보관)
+	resource, err := h.k8sClient.CreateConsoleResources(userID, userGroups, exchangeResp, defaultNamespace, cluster)
 	if err != nil {
 		logger.ErrorWithContext(c.Request.Context(), "Failed to create console resources", err, map[string]any{
 			"user_id": userID,
@@ -112,47 +177,35 @@ func (h *ConsoleHandler) HandleLaunchConsole(c *gin.Context) {
 	}
 
 	// 생성된 리소스 추적 저장
-	h.resources[resource.ID] = resource
+	if err := h.store.Save(resource); err != nil {
+		logger.WarnWithContext(c.Request.Context(), "Failed to save console session", map[string]any{
+			"user_id":     userID,
+			"resource_id": resource.ID,
+			"error":       err.Error(),
+		})
+	}
 
-	logger.InfoWithContext(c.Request.Context(), "Web console created successfully", map[string]any{
+	logger.InfoWithContext(c.Request.Context(), "Web console provisioning started", map[string]any{
 		"user_id":     userID,
 		"resource_id": resource.ID,
 		"console_url": resource.ConsoleURL,
 	})
 
-	utils.Response.SuccessWithMessage(c, "Web console created successfully", models.LaunchConsoleResponse{
+	utils.Response.SuccessWithMessage(c, "Web console provisioning started", models.LaunchConsoleResponse{
 		URL:        resource.ConsoleURL,
 		ResourceID: resource.ID,
+		Status:     models.ConsoleStatusProvisioning,
 	})
 }
 
 // HandleDeleteConsole 웹 콘솔 리소스 삭제
 func (h *ConsoleHandler) HandleDeleteConsole(c *gin.Context) {
-	ctx := context.Background()
-
-	// Authorization 헤더에서 Bearer 토큰(OIDC Access Token) 추출
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-		utils.Response.Unauthorized(c, "Authorization header with Bearer token is required")
-		return
-	}
-
-	oidcAccessToken := strings.TrimPrefix(authHeader, "Bearer ")
-
-	// OIDC Access Token 검증 (userinfo 엔드포인트 사용)
-	userInfo, err := h.validateOIDCAccessToken(ctx, oidcAccessToken)
-	if err != nil {
-		logger.WarnWithContext(c.Request.Context(), "Failed to verify OIDC token", map[string]any{
-			"error": err.Error(),
-		})
-		utils.Response.Unauthorized(c, fmt.Sprintf("Invalid OIDC token: %s", err.Error()))
+	// AuthMiddleware가 검증한 사용자 정보
+	identity, ok := requireIdentity(c)
+	if !ok {
 		return
 	}
-
-	userID := userInfo.PreferredUsername
-	if userID == "" {
-		userID = userInfo.Subject
-	}
+	userID := identity.UserID()
 
 	resourceID := c.Param("resourceId")
 	if resourceID == "" {
@@ -161,11 +214,15 @@ func (h *ConsoleHandler) HandleDeleteConsole(c *gin.Context) {
 	}
 
 	// 리소스 조회
-	resource, exists := h.resources[resourceID]
-	if !exists {
+	resource, err := h.store.Get(resourceID)
+	if errors.Is(err, kubernetes.ErrSessionNotFound) {
 		utils.Response.Error(c, models.ErrConsoleNotFound.WithDetails("Resource ID: "+resourceID))
 		return
 	}
+	if err != nil {
+		utils.Response.KubernetesError(c, "get console session", err)
+		return
+	}
 
 	// 사용자 권한 확인
 	if resource.UserID != userID {
@@ -191,8 +248,13 @@ func (h *ConsoleHandler) HandleDeleteConsole(c *gin.Context) {
 		return
 	}
 
-	// 메모리에서 제거
-	delete(h.resources, resourceID)
+	// 저장소에서 제거
+	if err := h.store.Delete(resourceID); err != nil {
+		logger.WarnWithContext(c.Request.Context(), "Failed to remove console session from store", map[string]any{
+			"resource_id": resourceID,
+			"error":       err.Error(),
+		})
+	}
 
 	logger.InfoWithContext(c.Request.Context(), "Web console deleted successfully", map[string]any{
 		"user_id":     userID,
@@ -206,40 +268,234 @@ func (h *ConsoleHandler) HandleDeleteConsole(c *gin.Context) {
 	})
 }
 
-// HandleListConsoles 사용자의 웹 콘솔 목록 조회
-func (h *ConsoleHandler) HandleListConsoles(c *gin.Context) {
-	ctx := context.Background()
+// HandleExtendConsole 웹 콘솔 만료 시간 연장
+func (h *ConsoleHandler) HandleExtendConsole(c *gin.Context) {
+	// AuthMiddleware가 검증한 사용자 정보
+	identity, ok := requireIdentity(c)
+	if !ok {
+		return
+	}
+	userID := identity.UserID()
 
-	// Authorization 헤더에서 Bearer 토큰(OIDC Access Token) 추출
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-		utils.Response.Unauthorized(c, "Authorization header with Bearer token is required")
+	resourceID := c.Param("resourceId")
+	if resourceID == "" {
+		utils.Response.ValidationError(c, "resourceId", "Resource ID is required")
+		return
+	}
+
+	// 요청 본문은 선택 사항 (비어 있으면 기본 TTL만큼 연장)
+	var req models.ExtendConsoleRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.Response.ValidationError(c, "body", err.Error())
+			return
+		}
+	}
+	if req.ExtendSeconds < 0 {
+		utils.Response.ValidationError(c, "extend_seconds", "Must be a positive number of seconds")
 		return
 	}
 
-	oidcAccessToken := strings.TrimPrefix(authHeader, "Bearer ")
+	cfg := config.Get()
+	extendSeconds := req.ExtendSeconds
+	if extendSeconds == 0 {
+		extendSeconds = cfg.Console.TTLSeconds
+	}
+	// 최대 수명보다 긴 연장은 의미가 없으므로 Duration 변환 전에 잘라 오버플로 방지
+	if extendSeconds > cfg.Console.MaxLifetime {
+		extendSeconds = cfg.Console.MaxLifetime
+	}
 
-	// OIDC Access Token 검증 (userinfo 엔드포인트 사용)
-	userInfo, err := h.validateOIDCAccessToken(ctx, oidcAccessToken)
+	resource, err := h.store.Get(resourceID)
+	if errors.Is(err, kubernetes.ErrSessionNotFound) {
+		utils.Response.Error(c, models.ErrConsoleNotFound.WithDetails("Resource ID: "+resourceID))
+		return
+	}
 	if err != nil {
-		logger.WarnWithContext(c.Request.Context(), "Failed to verify OIDC token", map[string]any{
-			"error": err.Error(),
+		utils.Response.KubernetesError(c, "get console session", err)
+		return
+	}
+
+	// 사용자 권한 확인
+	if resource.UserID != userID {
+		utils.Response.Forbidden(c, "You can only extend your own console resources")
+		return
+	}
+
+	// 현재 만료 시간보다 줄어들지 않고, 최대 수명을 넘지 않도록 계산
+	currentExpiresAt := resource.ExpiresAt
+	if currentExpiresAt.IsZero() {
+		currentExpiresAt = resource.CreatedAt.Add(time.Duration(cfg.Console.TTLSeconds) * time.Second)
+	}
+	maxExpiresAt := resource.CreatedAt.Add(time.Duration(cfg.Console.MaxLifetime) * time.Second)
+
+	newExpiresAt := time.Now().Add(time.Duration(extendSeconds) * time.Second)
+	if newExpiresAt.After(maxExpiresAt) {
+		newExpiresAt = maxExpiresAt
+	}
+	if !newExpiresAt.After(currentExpiresAt) {
+		utils.Response.Error(c, models.ErrConsoleMaxLifetimeReached.WithDetails(
+			fmt.Sprintf("Resource ID: %s, max expires at: %s", resourceID, maxExpiresAt.UTC().Format(time.RFC3339))))
+		return
+	}
+
+	if err := h.k8sClient.ExtendConsoleSession(resource, newExpiresAt); err != nil {
+		logger.ErrorWithContext(c.Request.Context(), "Failed to extend console session", err, map[string]any{
+			"user_id":     userID,
+			"resource_id": resourceID,
+		})
+		utils.Response.KubernetesError(c, "extend console session", err)
+		return
+	}
+
+	if err := h.store.Save(resource); err != nil {
+		logger.WarnWithContext(c.Request.Context(), "Failed to save extended console session", map[string]any{
+			"resource_id": resourceID,
+			"error":       err.Error(),
 		})
-		utils.Response.Unauthorized(c, fmt.Sprintf("Invalid OIDC token: %s", err.Error()))
+	}
+
+	logger.InfoWithContext(c.Request.Context(), "Web console expiry extended", map[string]any{
+		"user_id":     userID,
+		"resource_id": resourceID,
+		"expires_at":  newExpiresAt,
+	})
+
+	utils.Response.SuccessWithMessage(c, "Console expiry extended successfully", models.ExtendConsoleResponse{
+		ResourceID:   resourceID,
+		ExpiresAt:    newExpiresAt.UTC(),
+		MaxExpiresAt: maxExpiresAt.UTC(),
+	})
+}
+
+// HandleConsoleStatus 웹 콘솔 프로비저닝 상태 조회
+func (h *ConsoleHandler) HandleConsoleStatus(c *gin.Context) {
+	// AuthMiddleware가 검증한 사용자 정보
+	identity, ok := requireIdentity(c)
+	if !ok {
+		return
+	}
+	userID := identity.UserID()
+
+	resourceID := c.Param("resourceId")
+	if resourceID == "" {
+		utils.Response.ValidationError(c, "resourceId", "Resource ID is required")
+		return
+	}
+
+	status, err := h.k8sClient.GetConsoleStatus(config.Get().Console.Namespace, resourceID)
+	if errors.Is(err, kubernetes.ErrSessionNotFound) {
+		utils.Response.Error(c, models.ErrConsoleNotFound.WithDetails("Resource ID: "+resourceID))
+		return
+	}
+	if err != nil {
+		utils.Response.KubernetesError(c, "get console status", err)
+		return
+	}
+
+	// 사용자 권한 확인
+	if status.UserID != userID {
+		utils.Response.Forbidden(c, "You can only view your own console resources")
 		return
 	}
 
-	userID := userInfo.PreferredUsername
-	if userID == "" {
-		userID = userInfo.Subject
+	utils.Response.Success(c, status)
+}
+
+// HandleConsoleEvents 웹 콘솔 프로비저닝 진행 상황을 Server-Sent Events로 전달
+func (h *ConsoleHandler) HandleConsoleEvents(c *gin.Context) {
+	// AuthMiddleware가 검증한 사용자 정보
+	identity, ok := requireIdentity(c)
+	if !ok {
+		return
 	}
+	userID := identity.UserID()
 
-	// 사용자의 리소스 필터링
-	userResources := make([]*kubernetes.ConsoleResource, 0)
-	for _, resource := range h.resources {
-		if resource.UserID == userID {
-			userResources = append(userResources, resource)
+	resourceID := c.Param("resourceId")
+	if resourceID == "" {
+		utils.Response.ValidationError(c, "resourceId", "Resource ID is required")
+		return
+	}
+
+	resource, err := h.store.Get(resourceID)
+	if errors.Is(err, kubernetes.ErrSessionNotFound) {
+		utils.Response.Error(c, models.ErrConsoleNotFound.WithDetails("Resource ID: "+resourceID))
+		return
+	}
+	if err != nil {
+		utils.Response.KubernetesError(c, "get console session", err)
+		return
+	}
+
+	// 사용자 권한 확인
+	if resource.UserID != userID {
+		utils.Response.Forbidden(c, "You can only view your own console resources")
+		return
+	}
+
+	// 클라이언트 연결이 끊기거나 프로비저닝 제한 시간이 지나면 watch 종료
+	streamCtx, cancel := context.WithTimeout(c.Request.Context(), kubernetes.ProvisioningTimeout)
+	defer cancel()
+
+	events, err := h.k8sClient.WatchConsoleProgress(streamCtx, config.Get().Console.Namespace, resourceID)
+	if errors.Is(err, kubernetes.ErrSessionNotFound) {
+		utils.Response.Error(c, models.ErrConsoleNotFound.WithDetails("Resource ID: "+resourceID))
+		return
+	}
+	if err != nil {
+		utils.Response.KubernetesError(c, "watch console progress", err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // nginx 프록시 버퍼링 비활성화
+
+	keepAlive := time.NewTicker(consoleEventsKeepAlive)
+	defer keepAlive.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// 제한 시간 안에 ready/failed에 도달하지 못한 경우
+				if errors.Is(streamCtx.Err(), context.DeadlineExceeded) {
+					c.SSEvent(string(kubernetes.StepFailed), kubernetes.ProgressEvent{
+						Step:    kubernetes.StepFailed,
+						Reason:  "ProvisioningTimeout",
+						Message: fmt.Sprintf("console not ready after %s", kubernetes.ProvisioningTimeout),
+						Time:    time.Now(),
+					})
+				}
+				return false
+			}
+			c.SSEvent(string(event.Step), event)
+			return !event.IsTerminal()
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			return true
 		}
+	})
+}
+
+// HandleListConsoles 사용자의 웹 콘솔 목록 조회
+func (h *ConsoleHandler) HandleListConsoles(c *gin.Context) {
+	// AuthMiddleware가 검증한 사용자 정보
+	identity, ok := requireIdentity(c)
+	if !ok {
+		return
+	}
+	userID := identity.UserID()
+
+	// 사용자의 리소스 조회
+	userResources, err := h.store.ListByUser(userID)
+	if err != nil {
+		logger.ErrorWithContext(c.Request.Context(), "Failed to list console sessions", err, map[string]any{
+			"user_id": userID,
+		})
+		utils.Response.KubernetesError(c, "list console sessions", err)
+		return
 	}
 
 	utils.Response.Success(c, gin.H{
@@ -254,7 +510,7 @@ func (h *ConsoleHandler) HandleLogout(c *gin.Context) {
 	ctx := context.Background()
 
 	// JWT 쿠키에서 사용자 정보 추출
-	userID, err := h.extractUserFromCookie(c)
+	claims, err := h.extractUserFromCookie(c)
 	if err != nil {
 		logger.ErrorWithContext(ctx, "Failed to extract user from cookie", err, map[string]any{
 			"error": err.Error(),
@@ -263,10 +519,18 @@ func (h *ConsoleHandler) HandleLogout(c *gin.Context) {
 		return
 	}
 
+	userID := claims.UserID
+
 	logger.InfoWithContext(ctx, "Processing logout for user", map[string]any{
 		"user_id": userID,
 	})
 
+	// 서버 측 로그인 세션 제거 (IdP 로그아웃에 ID 토큰 힌트로 사용)
+	var idTokenHint string
+	if session := h.authHandler.sessions.Delete(claims.SessionID); session != nil {
+		idTokenHint = session.IDToken
+	}
+
 	// 1. 사용자별 모든 Web Console 리소스 정리
 	config := kubernetes.GetDefaultConfig()
 	err = h.k8sClient.DeleteUserResources(userID, config.Namespace)
@@ -277,14 +541,14 @@ func (h *ConsoleHandler) HandleLogout(c *gin.Context) {
 		// 리소스 정리 실패해도 로그아웃은 진행
 	}
 
-	// 2. 메모리에서 사용자 리소스 정리
-	h.cleanupUserResourcesFromMemory(userID)
+	// 2. 저장소에서 사용자 리소스 정리
+	h.cleanupUserResourcesFromStore(userID)
 
 	// 3. JWT 쿠키 삭제
-	c.SetCookie("portal-jwt", "", -1, "/", "", true, true)
+	setAuthCookie(c, auth.SessionCookieName, "", "/", -1)
 
-	// 4. Keycloak 로그아웃 URL 생성
-	logoutURL := h.generateKeycloakLogoutURL()
+	// 4. IdP 로그아웃 URL 생성
+	logoutURL := h.generateLogoutURL(idTokenHint)
 
 	logger.InfoWithContext(ctx, "Logout completed successfully", map[string]any{
 		"user_id":    userID,
@@ -299,83 +563,123 @@ func (h *ConsoleHandler) HandleLogout(c *gin.Context) {
 	})
 }
 
-// extractUserFromCookie JWT 쿠키에서 사용자 정보 추출
-func (h *ConsoleHandler) extractUserFromCookie(c *gin.Context) (string, error) {
+// extractUserFromCookie JWT 쿠키의 서명과 만료를 확인하고 사용자 정보 추출
+func (h *ConsoleHandler) extractUserFromCookie(c *gin.Context) (*auth.SessionClaims, error) {
 	// JWT 쿠키에서 토큰 추출
-	jwtToken, err := c.Cookie("portal-jwt")
+	jwtToken, err := c.Cookie(auth.SessionCookieName)
 	if err != nil {
-		return "", fmt.Errorf("portal-jwt cookie not found: %v", err)
+		return nil, fmt.Errorf("%s cookie not found: %v", auth.SessionCookieName, err)
 	}
 
-	// 간단한 JWT 토큰 파싱 (base64 디코딩)
-	// JWT 형식: header.payload.signature
-	parts := strings.Split(jwtToken, ".")
-	if len(parts) != 3 {
-		return "", fmt.Errorf("invalid JWT token format")
-	}
-
-	// payload 부분 디코딩
-	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	claims, err := h.authHandler.sessions.ParseCookieToken(jwtToken)
 	if err != nil {
-		return "", fmt.Errorf("failed to decode JWT payload: %v", err)
-	}
-
-	// JSON 파싱
-	var claims map[string]interface{}
-	if err := json.Unmarshal(payload, &claims); err != nil {
-		return "", fmt.Errorf("failed to parse JWT claims: %v", err)
+		return nil, err
 	}
-
-	// 사용자 ID 추출
-	userID, ok := claims["user_id"].(string)
-	if !ok {
-		return "", fmt.Errorf("user_id not found in JWT claims")
+	if claims.UserID == "" {
+		return nil, fmt.Errorf("user_id not found in JWT claims")
 	}
 
-	return userID, nil
+	return claims, nil
 }
 
-// cleanupUserResourcesFromMemory 메모리에서 사용자 리소스 정리
-func (h *ConsoleHandler) cleanupUserResourcesFromMemory(userID string) {
-	var resourcesToDelete []string
-
+// cleanupUserResourcesFromStore 저장소에서 사용자 리소스 정리
+func (h *ConsoleHandler) cleanupUserResourcesFromStore(userID string) {
 	// 사용자별 리소스 찾기
-	for resourceID, resource := range h.resources {
-		if resource.UserID == userID {
-			resourcesToDelete = append(resourcesToDelete, resourceID)
-		}
+	resourcesToDelete, err := h.store.ListByUser(userID)
+	if err != nil {
+		logger.ErrorWithContext(context.TODO(), "Failed to list user sessions from store", err, map[string]any{
+			"user_id": userID,
+		})
+		return
 	}
 
 	// 리소스 삭제
-	for _, resourceID := range resourcesToDelete {
-		delete(h.resources, resourceID)
-		logger.InfoWithContext(context.TODO(), "Removed resource from memory", map[string]any{
-			"resource_id": resourceID,
+	for _, resource := range resourcesToDelete {
+		if err := h.store.Delete(resource.ID); err != nil {
+			logger.WarnWithContext(context.TODO(), "Failed to remove resource from store", map[string]any{
+				"resource_id": resource.ID,
+				"user_id":     userID,
+				"error":       err.Error(),
+			})
+			continue
+		}
+		logger.InfoWithContext(context.TODO(), "Removed resource from store", map[string]any{
+			"resource_id": resource.ID,
 			"user_id":     userID,
 		})
 	}
 
 	if len(resourcesToDelete) > 0 {
-		logger.InfoWithContext(context.TODO(), "Memory cleanup completed", map[string]any{
+		logger.InfoWithContext(context.TODO(), "Store cleanup completed", map[string]any{
 			"user_id":           userID,
 			"cleaned_resources": len(resourcesToDelete),
 		})
 	}
 }
 
-// generateKeycloakLogoutURL Keycloak 로그아웃 URL 생성
-func (h *ConsoleHandler) generateKeycloakLogoutURL() string {
-	config := config.Get()
+// generateLogoutURL discovery한 end_session_endpoint로 RP-initiated logout URL 생성
+// IdP가 end_session_endpoint를 제공하지 않으면(Dex 등) 프론트엔드 URL로 바로 이동
+func (h *ConsoleHandler) generateLogoutURL(idTokenHint string) string {
+	cfg := config.Get()
+
+	endpoints, err := auth.Endpoints()
+	if err != nil || endpoints.EndSessionEndpoint == "" {
+		return cfg.OIDC.PostLogoutRedirectURL
+	}
+
+	// {end_session_endpoint}?client_id={client_id}&post_logout_redirect_uri={redirect_uri}
+	logoutURL, err := url.Parse(endpoints.EndSessionEndpoint)
+	if err != nil {
+		return cfg.OIDC.PostLogoutRedirectURL
+	}
+	query := logoutURL.Query()
+	query.Set("client_id", cfg.OIDC.ClientID)
+	query.Set("post_logout_redirect_uri", cfg.OIDC.PostLogoutRedirectURL)
+	if idTokenHint != "" {
+		query.Set("id_token_hint", idTokenHint)
+	}
+	logoutURL.RawQuery = query.Encode()
+
+	return logoutURL.String()
+}
+
+// reconcileSessions 시작 시 클러스터 라벨로부터 콘솔 세션을 재구성하고 부분 세션을 정리
+func (h *ConsoleHandler) reconcileSessions() {
+	ctx := context.TODO()
+	cfg := config.Get()
+
+	result, err := h.k8sClient.ReconcileConsoleSessions(cfg.Console.Namespace, kubernetes.PartialSessionGracePeriod)
+	if err != nil {
+		logger.ErrorWithContext(ctx, "Failed to reconcile console sessions", err, map[string]any{
+			"namespace": cfg.Console.Namespace,
+		})
+		return
+	}
+
+	for _, resource := range result.Complete {
+		if err := h.store.Save(resource); err != nil {
+			logger.WarnWithContext(ctx, "Failed to restore console session", map[string]any{
+				"resource_id": resource.ID,
+				"user_id":     resource.UserID,
+				"error":       err.Error(),
+			})
+		}
+	}
 
-	// Keycloak 로그아웃 URL 형식:
-	// {issuer}/protocol/openid-connect/logout?client_id={client_id}&post_logout_redirect_uri={redirect_uri}
-	logoutURL := fmt.Sprintf("%s/protocol/openid-connect/logout?client_id=%s&post_logout_redirect_uri=%s",
-		config.OIDC.IssuerURL,
-		config.OIDC.ClientID,
-		"https://front.miribit.cloud", // 프론트엔드 URL로 리다이렉트
-	)
+	for _, partial := range result.Partial {
+		logger.WarnWithContext(ctx, "Found partial console session", map[string]any{
+			"resource_id": partial.Resource.ID,
+			"user_id":     partial.Resource.UserID,
+			"missing":     partial.Missing,
+			"collected":   partial.Collected,
+		})
+	}
 
-	return logoutURL
+	logger.InfoWithContext(ctx, "Console session reconciliation completed", map[string]any{
+		"namespace":         cfg.Console.Namespace,
+		"restored_sessions": len(result.Complete),
+		"partial_sessions":  len(result.Partial),
+	})
 }
 
 // startCleanupRoutine 백그라운드 정리 루틴 시작
@@ -388,139 +692,144 @@ func (h *ConsoleHandler) startCleanupRoutine() {
 	}
 }
 
+// startTokenRefreshRoutine 콘솔 토큰 갱신 루틴 시작 (CONSOLE_TOKEN_REFRESH_INTERVAL_SECONDS가 0이면 비활성화)
+func (h *ConsoleHandler) startTokenRefreshRoutine() {
+	cfg := config.Get()
+	if cfg.Console.TokenRefreshIntervalSeconds <= 0 {
+		logger.Info("Console token refresh is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(cfg.Console.TokenRefreshIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	refreshBefore := time.Duration(cfg.Console.TokenRefreshBeforeSeconds) * time.Second
+	for range ticker.C {
+		refreshed, err := h.k8sClient.RefreshConsoleTokens(kubernetes.GetDefaultConfig().Namespace, refreshBefore)
+		if err != nil {
+			logger.Error("Failed to refresh console tokens", err)
+			continue
+		}
+		if refreshed > 0 {
+			logger.Info(fmt.Sprintf("Refreshed %d console tokens", refreshed))
+		}
+	}
+}
+
 // cleanupExpiredResources 만료된 리소스 정리
 func (h *ConsoleHandler) cleanupExpiredResources() {
 	config := kubernetes.GetDefaultConfig()
 
+	// 유휴 세션을 먼저 정리
+	h.cleanupIdleResources(config.Namespace)
+
 	// 쿠버네티스에서 만료된 리소스 정리
-	err := h.k8sClient.CleanupExpiredResources(config.Namespace)
+	reaped, err := h.k8sClient.CleanupExpiredResources(config.Namespace)
 	if err != nil {
 		logger.Error("Failed to cleanup expired resources", err)
 	}
+	h.removeReapedFromStore(reaped)
+
+	// 저장소에서 TTL이 지난 리소스 정리
+	resources, err := h.store.List()
+	if err != nil {
+		logger.Error("Failed to list console sessions from store", err)
+		return
+	}
 
-	// 메모리에서 오래된 리소스 정리 (1시간 이상)
-	cutoff := time.Now().Add(-1 * time.Hour)
+	ttl := time.Duration(config.TTLSeconds) * time.Second
 	cleanedCount := 0
-	for id, resource := range h.resources {
-		if resource.CreatedAt.Before(cutoff) {
-			logger.InfoWithContext(context.TODO(), "Removing expired resource from memory", map[string]any{
-				"resource_id": id,
+	for _, resource := range resources {
+		expiresAt := resource.ExpiresAt
+		if expiresAt.IsZero() {
+			expiresAt = resource.CreatedAt.Add(ttl)
+		}
+		if time.Now().After(expiresAt) {
+			logger.InfoWithContext(context.TODO(), "Removing expired resource from store", map[string]any{
+				"resource_id": resource.ID,
 				"user_id":     resource.UserID,
 				"created_at":  resource.CreatedAt,
 				"deployment":  resource.DeploymentName,
 				"service":     resource.ServiceName,
 			})
-			delete(h.resources, id)
+			if err := h.store.Delete(resource.ID); err != nil {
+				logger.Error("Failed to remove expired resource from store", err)
+				continue
+			}
 			cleanedCount++
 		}
 	}
 
 	if cleanedCount > 0 {
-		logger.InfoWithContext(context.TODO(), "Memory cleanup completed", map[string]any{
+		logger.InfoWithContext(context.TODO(), "Store cleanup completed", map[string]any{
 			"cleaned_resources": cleanedCount,
 		})
 	}
 }
 
-// validateOIDCAccessToken OIDC Access Token을 userinfo 엔드포인트로 검증
-func (h *ConsoleHandler) validateOIDCAccessToken(ctx context.Context, accessToken string) (*OIDCUserInfo, error) {
-	if err := checkTokenExpiration(accessToken); err != nil {
-		return nil, err
-	}
-
-	issuerURL := os.Getenv("OIDC_ISSUER_URL")
-	if issuerURL == "" {
-		return nil, fmt.Errorf("OIDC_ISSUER_URL environment variable is required")
-	}
-
-	userInfoURL := issuerURL + "/protocol/openid-connect/userinfo"
-
-	req, err := http.NewRequestWithContext(ctx, "GET", userInfoURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create userinfo request: %v", err)
+// cleanupIdleResources 터미널 활동이 없는 세션 정리 (활동 보고가 설정된 경우에만)
+func (h *ConsoleHandler) cleanupIdleResources(namespace string) {
+	cfg := config.Get()
+	if cfg.Console.IdleTimeoutMinutes <= 0 || cfg.Console.HeartbeatURL == "" {
+		return
 	}
 
-	req.Header.Set("Authorization", "Bearer "+accessToken)
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	idleTimeout := time.Duration(cfg.Console.IdleTimeoutMinutes) * time.Minute
+	reaped, err := h.k8sClient.CleanupIdleResources(namespace, idleTimeout)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call userinfo endpoint: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("userinfo endpoint returned status %d", resp.StatusCode)
+		logger.Error("Failed to cleanup idle resources", err)
 	}
-
-	var userInfo OIDCUserInfo
-	if err := json.NewDecoder(resp.Body).Decode(&userInfo); err != nil {
-		return nil, fmt.Errorf("failed to decode userinfo response: %v", err)
-	}
-
-	return &userInfo, nil
+	h.removeReapedFromStore(reaped)
 }
 
-// checkTokenExpiration JWT 토큰의 만료 시간을 확인 (서명 검증 없이 클레임만 확인)
-func checkTokenExpiration(token string) error {
-	parts := strings.Split(token, ".")
-	if len(parts) != 3 {
-		return fmt.Errorf("invalid token format")
+// removeReapedFromStore 정리된 세션을 저장소에서 제거
+func (h *ConsoleHandler) removeReapedFromStore(resourceIDs []string) {
+	for _, resourceID := range resourceIDs {
+		if err := h.store.Delete(resourceID); err != nil {
+			logger.Error("Failed to remove reaped resource from store", err)
+		}
 	}
+}
 
-	// JWT payload 디코딩
-	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
-	if err != nil {
-		return fmt.Errorf("failed to decode token payload: %v", err)
+// HandleConsoleHeartbeat 콘솔 Pod에서 보고하는 터미널 활동 기록
+func (h *ConsoleHandler) HandleConsoleHeartbeat(c *gin.Context) {
+	resourceID := c.Param("resourceId")
+	if resourceID == "" {
+		utils.Response.ValidationError(c, "resourceId", "Resource ID is required")
+		return
 	}
 
-	var claims struct {
-		Exp int64 `json:"exp"`
-	}
-	if err := json.Unmarshal(payload, &claims); err != nil {
-		return fmt.Errorf("failed to parse token claims: %v", err)
+	// 세션별 HMAC 토큰으로 콘솔 Pod 인증
+	if !auth.VerifyHeartbeatToken(resourceID, c.GetHeader("X-Console-Token")) {
+		utils.Response.Error(c, models.ErrTokenInvalid.WithDetails("Invalid console heartbeat token"))
+		return
 	}
 
-	// 만료 시간 확인
-	if claims.Exp == 0 {
-		return fmt.Errorf("token does not contain expiration claim")
+	cfg := config.Get()
+	err := h.k8sClient.RecordConsoleActivity(cfg.Console.Namespace, resourceID)
+	if errors.Is(err, kubernetes.ErrSessionNotFound) {
+		utils.Response.Error(c, models.ErrConsoleNotFound.WithDetails("Resource ID: "+resourceID))
+		return
 	}
-
-	expirationTime := time.Unix(claims.Exp, 0)
-	if time.Now().After(expirationTime) {
-		return fmt.Errorf("token has expired at %s", expirationTime.Format(time.RFC3339))
+	if err != nil {
+		logger.ErrorWithContext(c.Request.Context(), "Failed to record console activity", err, map[string]any{
+			"resource_id": resourceID,
+		})
+		utils.Response.KubernetesError(c, "record console activity", err)
+		return
 	}
 
-	return nil
+	c.Status(http.StatusNoContent)
 }
 
 // HandleDeleteUserResources 사용자별 모든 Web Console 리소스 삭제
 func (h *ConsoleHandler) HandleDeleteUserResources(c *gin.Context) {
-	ctx := context.Background()
-
-	// Authorization 헤더에서 Bearer 토큰(OIDC Access Token) 추출
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-		utils.Response.Unauthorized(c, "Authorization header with Bearer token is required")
-		return
-	}
-
-	oidcAccessToken := strings.TrimPrefix(authHeader, "Bearer ")
-
-	// OIDC Access Token 검증 (userinfo 엔드포인트 사용)
-	userInfo, err := h.validateOIDCAccessToken(ctx, oidcAccessToken)
-	if err != nil {
-		logger.WarnWithContext(c.Request.Context(), "Failed to verify OIDC token", map[string]any{
-			"error": err.Error(),
-		})
-		utils.Response.Unauthorized(c, fmt.Sprintf("Invalid OIDC token - %s", err.Error()))
+	// AuthMiddleware가 검증한 사용자 정보
+	identity, ok := requireIdentity(c)
+	if !ok {
 		return
 	}
-
-	userID := userInfo.PreferredUsername
-	if userID == "" {
-		userID = userInfo.Subject
-	}
+	userID := identity.UserID()
 
 	// 사용자별 모든 리소스 삭제
 	logger.InfoWithContext(c.Request.Context(), "Deleting all console resources for user", map[string]any{
@@ -528,7 +837,7 @@ func (h *ConsoleHandler) HandleDeleteUserResources(c *gin.Context) {
 	})
 
 	cfg := config.Get()
-	err = h.k8sClient.DeleteUserResources(userID, cfg.Console.Namespace)
+	err := h.k8sClient.DeleteUserResources(userID, cfg.Console.Namespace)
 	if err != nil {
 		logger.ErrorWithContext(c.Request.Context(), "Failed to delete user console resources", err, map[string]any{
 			"user_id": userID,
@@ -537,12 +846,8 @@ func (h *ConsoleHandler) HandleDeleteUserResources(c *gin.Context) {
 		return
 	}
 
-	// 메모리에서 해당 사용자의 모든 리소스 제거
-	for resourceID, resource := range h.resources {
-		if resource.UserID == userID {
-			delete(h.resources, resourceID)
-		}
-	}
+	// 저장소에서 해당 사용자의 모든 리소스 제거
+	h.cleanupUserResourcesFromStore(userID)
 
 	logger.InfoWithContext(c.Request.Context(), "Successfully deleted all console resources for user", map[string]any{
 		"user_id": userID,
@@ -553,14 +858,3 @@ func (h *ConsoleHandler) HandleDeleteUserResources(c *gin.Context) {
 		"user_id": userID,
 	})
 }
-
-// OIDCUserInfo OIDC userinfo 응답 구조체
-type OIDCUserInfo struct {
-	Subject           string `json:"sub"`
-	Email             string `json:"email"`
-	Name              string `json:"name"`
-	PreferredUsername string `json:"preferred_username"`
-	GivenName         string `json:"given_name"`
-	FamilyName        string `json:"family_name"`
-	EmailVerified     bool   `json:"email_verified"`
-}
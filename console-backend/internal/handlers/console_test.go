@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"portal-backend/internal/auth"
+	"portal-backend/internal/config"
+	"portal-backend/internal/kubernetes"
+	"portal-backend/internal/middleware"
+	"portal-backend/internal/models"
+)
+
+func TestHandleExtendConsole(t *testing.T) {
+	setConsoleConfig(t, func(c *config.ConsoleConfig) {
+		c.TTLSeconds = 3600
+		c.MaxLifetime = 7200
+	})
+
+	// 어노테이션은 초 단위로 기록되므로 비교할 시간도 초 단위로 맞춤
+	now := time.Now().Truncate(time.Second)
+	fresh := testConsole{id: "fresh", user: "alice", cluster: "local", namespace: "blue",
+		created: now.Add(-10 * time.Minute), expires: now.Add(50 * time.Minute)}
+	old := testConsole{id: "old", user: "alice", cluster: "local", namespace: "blue",
+		created: now.Add(-90 * time.Minute), expires: now.Add(10 * time.Minute)}
+	maxed := testConsole{id: "maxed", user: "alice", cluster: "local", namespace: "blue",
+		created: now.Add(-100 * time.Minute), expires: now.Add(20 * time.Minute)}
+	bobs := testConsole{id: "bobs", user: "bob", cluster: "local", namespace: "blue",
+		created: now.Add(-10 * time.Minute), expires: now.Add(50 * time.Minute)}
+
+	tests := []struct {
+		name          string
+		resourceID    string
+		body          string
+		wantStatus    int
+		wantCode      string
+		wantExpiresAt time.Time // 응답과 Deployment 어노테이션의 새 만료 시간 (zero면 확인하지 않음)
+	}{
+		{
+			name:          "empty body extends by ttl",
+			resourceID:    fresh.id,
+			wantStatus:    http.StatusOK,
+			wantExpiresAt: now.Add(time.Hour),
+		},
+		{
+			name:          "explicit extension",
+			resourceID:    fresh.id,
+			body:          `{"extend_seconds": 5400}`,
+			wantStatus:    http.StatusOK,
+			wantExpiresAt: now.Add(90 * time.Minute),
+		},
+		{
+			name:          "clamped to max lifetime",
+			resourceID:    old.id,
+			body:          `{"extend_seconds": 3600}`,
+			wantStatus:    http.StatusOK,
+			wantExpiresAt: old.created.Add(2 * time.Hour),
+		},
+		{
+			name:          "huge extension does not overflow",
+			resourceID:    old.id,
+			body:          `{"extend_seconds": 9223372036854775807}`,
+			wantStatus:    http.StatusOK,
+			wantExpiresAt: old.created.Add(2 * time.Hour),
+		},
+		{
+			name:       "already at max lifetime",
+			resourceID: maxed.id,
+			body:       `{"extend_seconds": 600}`,
+			wantStatus: http.StatusConflict,
+			wantCode:   models.ErrConsoleMaxLifetimeReached.Code,
+		},
+		{
+			name:       "negative extension",
+			resourceID: fresh.id,
+			body:       `{"extend_seconds": -1}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   models.ErrInvalidInput.Code,
+		},
+		{
+			name:       "other user's console",
+			resourceID: bobs.id,
+			wantStatus: http.StatusForbidden,
+			wantCode:   models.ErrInsufficientPermissions.Code,
+		},
+		{
+			name:       "unknown console",
+			resourceID: "missing",
+			wantStatus: http.StatusNotFound,
+			wantCode:   models.ErrConsoleNotFound.Code,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, clientset := newTestConsoleHandler(t, fresh, old, maxed, bobs)
+
+			router := gin.New()
+			router.POST("/api/console/:resourceId/extend", func(c *gin.Context) {
+				c.Set(middleware.IdentityKey, &auth.Identity{Subject: "alice-sub", Username: "alice"})
+			}, handler.HandleExtendConsole)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/api/console/"+tt.resourceID+"/extend", strings.NewReader(tt.body))
+			request.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body: %s)", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+
+			var body struct {
+				Data  models.ExtendConsoleResponse `json:"data"`
+				Error struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if tt.wantCode != "" && body.Error.Code != tt.wantCode {
+				t.Errorf("error code = %q, want %q", body.Error.Code, tt.wantCode)
+			}
+			if tt.wantExpiresAt.IsZero() {
+				return
+			}
+
+			// 요청 처리 중 흐른 시간만큼의 오차 허용
+			if diff := body.Data.ExpiresAt.Sub(tt.wantExpiresAt); diff < 0 || diff > 5*time.Second {
+				t.Errorf("expires_at = %s, want %s", body.Data.ExpiresAt, tt.wantExpiresAt)
+			}
+			deployment, err := clientset.AppsV1().Deployments(testNamespace).Get(context.Background(), "console-"+tt.resourceID, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get deployment: %v", err)
+			}
+			annotated := kubernetes.SessionExpiresAt(deployment.ObjectMeta, time.Hour)
+			if !annotated.Equal(body.Data.ExpiresAt.Truncate(time.Second)) {
+				t.Errorf("deployment expires-at annotation = %s, want %s", annotated, body.Data.ExpiresAt)
+			}
+		})
+	}
+}
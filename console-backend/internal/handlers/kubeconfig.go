@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"portal-backend/internal/config"
+	"portal-backend/internal/kubernetes"
+	"portal-backend/internal/logger"
+	"portal-backend/internal/models"
+	"portal-backend/internal/utils"
+)
+
+// HandleDownloadKubeconfig 로컬 kubectl용 kubeconfig 다운로드
+// namespace 파라미터로 current-context를 선택할 수 있음 (콘솔 실행과 같은 네임스페이스 권한 확인)
+// 사용자 인증은 kubectl oidc-login 플러그인이 수행하므로 파일에 토큰이 포함되지 않음
+func (h *ConsoleHandler) HandleDownloadKubeconfig(c *gin.Context) {
+	// AuthMiddleware가 검증한 사용자 정보
+	identity, ok := requireIdentity(c)
+	if !ok {
+		return
+	}
+	userID := identity.UserID()
+	userGroups := identity.UserGroups()
+
+	cluster, clusterErr := resolveCluster(c.Query("cluster"), userGroups.Groups)
+	if clusterErr != nil {
+		utils.Response.Error(c, clusterErr)
+		return
+	}
+
+	defaultNamespace, namespaceErr := resolveNamespace(c.Query("namespace"), userGroups)
+	if namespaceErr != nil {
+		utils.Response.Error(c, namespaceErr)
+		return
+	}
+
+	// 로컬 kubectl은 클러스터 밖에서 사용하므로 CA 없이 insecure-skip-tls-verify로 내려주지 않음
+	if _, caData := kubernetes.ClusterEndpoint(cluster); caData == "" {
+		logger.WarnWithContext(c.Request.Context(), "Kubeconfig download refused: cluster has no CA data", map[string]any{
+			"user_id": userID,
+			"cluster": cluster.Name,
+		})
+		utils.Response.Error(c, models.ErrServiceUnavailable.WithDetails(fmt.Sprintf("Cluster %s has no CA certificate configured for kubeconfig download", cluster.Name)))
+		return
+	}
+
+	kubeconfig, err := kubernetes.GenerateUserKubeconfig(kubernetes.KubeconfigOptions{
+		Cluster:          cluster,
+		UserName:         userID,
+		Namespaces:       userGroups.Namespaces(),
+		DefaultNamespace: defaultNamespace,
+		Credential: kubernetes.KubeconfigCredential{
+			Exec: oidcLoginExec(cluster),
+		},
+	})
+	if err != nil {
+		utils.Response.InternalError(c, fmt.Errorf("failed to generate kubeconfig: %w", err))
+		return
+	}
+
+	logger.InfoWithContext(c.Request.Context(), "Kubeconfig downloaded", map[string]any{
+		"user_id": userID,
+		"cluster": cluster.Name,
+	})
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="kubeconfig-%s.yaml"`, cluster.Name))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/yaml; charset=utf-8", []byte(kubeconfig))
+}
+
+// oidcLoginExec kubectl oidc-login(kubelogin) 플러그인 exec 설정
+// 클러스터에 audience가 지정되어 있으면 해당 클라이언트로, 없으면 KUBERNETES_CLIENT_ID로 로그인
+func oidcLoginExec(cluster *config.ClusterConfig) *kubernetes.KubeconfigExec {
+	cfg := config.Get()
+
+	clientID := cluster.Audience
+	if clientID == "" {
+		clientID = cfg.OIDC.KubernetesClientID
+	}
+
+	return &kubernetes.KubeconfigExec{
+		Command: "kubectl",
+		Args: []string{
+			"oidc-login",
+			"get-token",
+			"--oidc-issuer-url=" + cfg.OIDC.IssuerURL,
+			"--oidc-client-id=" + clientID,
+		},
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"portal-backend/internal/auth"
+	"portal-backend/internal/config"
+	"portal-backend/internal/logger"
+	"portal-backend/internal/models"
+	"portal-backend/internal/utils"
+)
+
+// loginStateCookieName 로그인 요청을 시작한 브라우저를 콜백과 묶는 쿠키 (로그인 CSRF 방지)
+const loginStateCookieName = "portal-login-state"
+
+// setAuthCookie /auth 흐름의 HttpOnly 쿠키 설정 (Secure, SameSite=Lax)
+func setAuthCookie(c *gin.Context, name, value, path string, maxAge int) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(name, value, maxAge, path, "", true, true)
+}
+
+// HandleLogin 인가 코드 로그인 시작 (state, PKCE, nonce를 저장하고 IdP로 리다이렉트)
+// redirect 쿼리로 로그인 후 돌아갈 프론트엔드 경로 지정 가능
+func (h *AuthHandler) HandleLogin(c *gin.Context) {
+	authURL, state, err := h.sessions.BeginLogin(c.Query("redirect"))
+	if err != nil {
+		logger.ErrorWithContext(c.Request.Context(), "Failed to start login", err, nil)
+		utils.Response.InternalError(c, err)
+		return
+	}
+
+	setAuthCookie(c, loginStateCookieName, state, "/auth", 600)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// HandleCallback IdP 콜백 처리 (state 확인, PKCE로 코드 교환, 서버 측 세션 생성 후 portal-jwt 쿠키 발급)
+func (h *AuthHandler) HandleCallback(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if idpError := c.Query("error"); idpError != "" {
+		logger.WarnWithContext(ctx, "Login rejected by identity provider", map[string]any{
+			"error":             idpError,
+			"error_description": c.Query("error_description"),
+		})
+		utils.Response.Error(c, models.ErrInvalidCredentials.WithDetails(idpError+": "+c.Query("error_description")))
+		return
+	}
+
+	state := c.Query("state")
+	code := c.Query("code")
+	if state == "" || code == "" {
+		utils.Response.Error(c, models.ErrMissingParameter.WithDetails("Parameters: state, code"))
+		return
+	}
+
+	// 로그인을 시작한 브라우저인지 확인
+	stateCookie, err := c.Cookie(loginStateCookieName)
+	setAuthCookie(c, loginStateCookieName, "", "/auth", -1)
+	if err != nil || stateCookie != state {
+		logger.WarnWithContext(ctx, "Login state does not match the browser", nil)
+		utils.Response.Error(c, models.ErrInvalidInput.WithDetails("Login state does not match this browser"))
+		return
+	}
+
+	session, redirectURL, err := h.sessions.CompleteLogin(ctx, state, code)
+	if err != nil {
+		logger.WarnWithContext(ctx, "Failed to complete login", map[string]any{
+			"error": err.Error(),
+		})
+		utils.Response.Error(c, models.ErrInvalidCredentials.WithDetails(err.Error()))
+		return
+	}
+
+	cookieToken, err := h.sessions.IssueCookieToken(session)
+	if err != nil {
+		h.sessions.Delete(session.SessionID)
+		logger.ErrorWithContext(ctx, "Failed to issue session cookie", err, map[string]any{
+			"user_id": session.UserID,
+		})
+		utils.Response.InternalError(c, err)
+		return
+	}
+
+	setAuthCookie(c, auth.SessionCookieName, cookieToken, "/", config.Get().JWT.SessionTTLSeconds)
+
+	logger.InfoWithContext(ctx, "User logged in", map[string]any{
+		"user_id":          session.UserID,
+		"token_expires_at": session.ExpiresAt,
+	})
+
+	c.Redirect(http.StatusFound, redirectURL)
+}
+
+// HandleRefresh portal-jwt 쿠키가 가리키는 세션의 토큰을 리프레시 토큰으로 갱신
+// 리프레시 토큰이 만료되었거나 폐기되었으면 세션과 쿠키를 지우고 401 반환
+func (h *AuthHandler) HandleRefresh(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	cookie, err := c.Cookie(auth.SessionCookieName)
+	if err != nil {
+		utils.Response.Error(c, models.ErrSessionNotFound)
+		return
+	}
+	claims, err := h.sessions.ParseCookieToken(cookie)
+	if err != nil {
+		setAuthCookie(c, auth.SessionCookieName, "", "/", -1)
+		utils.Response.Error(c, models.ErrSessionNotFound.WithDetails(err.Error()))
+		return
+	}
+
+	session, err := h.sessions.Refresh(claims.SessionID)
+	if err != nil {
+		logger.WarnWithContext(ctx, "Failed to refresh login session", map[string]any{
+			"user_id": claims.UserID,
+			"error":   err.Error(),
+		})
+		// IdP 장애 등 일시적인 실패는 세션과 쿠키를 유지하여 다시 시도할 수 있게 함
+		if !errors.Is(err, auth.ErrSessionNotFound) {
+			utils.Response.Error(c, models.ErrServiceUnavailable.WithDetails("Token refresh failed, try again later").WithCause(err))
+			return
+		}
+		setAuthCookie(c, auth.SessionCookieName, "", "/", -1)
+		utils.Response.Error(c, models.ErrSessionNotFound.WithDetails(err.Error()))
+		return
+	}
+
+	utils.Response.Success(c, models.RefreshSessionResponse{
+		UserID:    session.UserID,
+		ExpiresAt: session.ExpiresAt,
+	})
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"portal-backend/internal/config"
+	"portal-backend/internal/kubernetes"
+)
+
+const testNamespace = "console"
+
+// TestMain 핸들러가 참조하는 전역 설정을 로드
+func TestMain(m *testing.M) {
+	env := map[string]string{
+		"OIDC_CLIENT_ID":      "portal-app",
+		"OIDC_CLIENT_SECRET":  "secret",
+		"OIDC_ISSUER_URL":     "https://keycloak.example.com/realms/test",
+		"OIDC_REDIRECT_URL":   "https://portal.example.com/auth/callback",
+		"JWT_SECRET_KEY":      "test-secret",
+		"GROUP_MAPPINGS_FILE": "",
+		"CLUSTERS_FILE":       "",
+		"CONSOLE_NAMESPACE":   testNamespace,
+	}
+	for key, value := range env {
+		os.Setenv(key, value)
+	}
+
+	if _, err := config.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load test config: %v\n", err)
+		os.Exit(1)
+	}
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// setConsoleConfig 테스트 동안 콘솔 설정을 바꾸고 끝나면 복원
+func setConsoleConfig(t *testing.T, mutate func(*config.ConsoleConfig)) {
+	t.Helper()
+	cfg := config.Get()
+	saved := cfg.Console
+	mutate(&cfg.Console)
+	t.Cleanup(func() { cfg.Console = saved })
+}
+
+// testConsole 테스트용 콘솔 세션 Deployment
+type testConsole struct {
+	id        string
+	user      string
+	cluster   string
+	namespace string // 콘솔의 기본 작업 네임스페이스
+	created   time.Time
+	expires   time.Time
+}
+
+// objects 세션 라벨과 만료 어노테이션이 붙은 Deployment, Service, Secret, Ingress
+func (tc testConsole) objects() []runtime.Object {
+	meta := metav1.ObjectMeta{
+		Name:      "console-" + tc.id,
+		Namespace: testNamespace,
+		Labels: map[string]string{
+			"app":     "web-console",
+			"user":    tc.user,
+			"session": tc.id,
+			"cluster": tc.cluster,
+		},
+		Annotations: map[string]string{
+			kubernetes.AnnotationCreatedAt:       tc.created.UTC().Format(time.RFC3339),
+			kubernetes.AnnotationExpiresAt:       tc.expires.UTC().Format(time.RFC3339),
+			kubernetes.AnnotationTargetNamespace: tc.namespace,
+		},
+		CreationTimestamp: metav1.NewTime(tc.created),
+	}
+	return []runtime.Object{
+		&appsv1.Deployment{ObjectMeta: *meta.DeepCopy()},
+		&corev1.Service{ObjectMeta: *meta.DeepCopy()},
+		&corev1.Secret{ObjectMeta: *meta.DeepCopy()},
+		&networkingv1.Ingress{ObjectMeta: *meta.DeepCopy()},
+	}
+}
+
+// newTestConsoleHandler fake clientset과 클러스터 라벨 기반 세션 저장소를 사용하는 핸들러
+// 백그라운드 정리/토큰 갱신 루틴은 시작하지 않음
+func newTestConsoleHandler(t *testing.T, consoles ...testConsole) (*ConsoleHandler, *fake.Clientset) {
+	t.Helper()
+	objects := make([]runtime.Object, 0, 4*len(consoles))
+	for _, console := range consoles {
+		objects = append(objects, console.objects()...)
+	}
+
+	clientset := fake.NewSimpleClientset(objects...)
+	client := kubernetes.NewClientWithClientset(clientset, testNamespace)
+	t.Cleanup(client.Close)
+
+	return &ConsoleHandler{
+		k8sClient:     client,
+		store:         kubernetes.NewKubernetesSessionStore(client, testNamespace),
+		launchLocks:   newLaunchLocks(),
+		capacityLocks: newLaunchLocks(),
+	}, clientset
+}
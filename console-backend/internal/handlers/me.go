@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"portal-backend/internal/logger"
+	"portal-backend/internal/models"
+	"portal-backend/internal/utils"
+)
+
+// HandleGetMe 인증된 사용자 프로필 조회
+// 역할, 허용 네임스페이스, 기본 네임스페이스, 사용 가능한 클러스터, 실행 중인 콘솔 개수를 함께 반환
+func (h *ConsoleHandler) HandleGetMe(c *gin.Context) {
+	// AuthMiddleware가 검증한 사용자 정보
+	identity, ok := requireIdentity(c)
+	if !ok {
+		return
+	}
+	userID := identity.UserID()
+	userGroups := identity.UserGroups()
+
+	consoles, err := h.store.ListByUser(userID)
+	if err != nil {
+		logger.ErrorWithContext(c.Request.Context(), "Failed to list console sessions", err, map[string]any{
+			"user_id": userID,
+		})
+		utils.Response.KubernetesError(c, "list console sessions", err)
+		return
+	}
+
+	grants := userGroups.RoleGrants()
+	utils.Response.Success(c, models.UserProfile{
+		UserID:         userID,
+		Subject:        identity.Subject,
+		Username:       identity.Username,
+		Email:          identity.Email,
+		Name:           identity.Name,
+		Groups:         identity.Groups,
+		Role:           identity.Role,
+		Roles:          userGroups.RoleSummary(),
+		NamespaceRoles: identity.NamespaceRoles,
+		ClusterAdmin:   grants.ClusterAdmin,
+
+		Namespaces:       userGroups.Namespaces(),
+		DefaultNamespace: userGroups.DetermineDefaultNamespace(),
+		Clusters:         availableClusters(identity.Groups),
+		ActiveConsoles:   len(consoles),
+	})
+}
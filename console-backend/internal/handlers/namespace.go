@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"portal-backend/internal/auth"
+	"portal-backend/internal/config"
+	"portal-backend/internal/models"
+	"portal-backend/internal/utils"
+)
+
+// resolveNamespace namespace 파라미터를 사용자 그룹이 부여한 네임스페이스와 비교하여 콘솔 기본 네임스페이스 결정
+// 이름이 비어 있으면 그룹 매핑 규칙으로 결정한 기본 네임스페이스 사용
+// CONSOLE_MIN_NAMESPACE_ROLE이 설정되어 있으면 해당 네임스페이스에 그 역할 이상이 있어야 함
+func resolveNamespace(name string, userGroups *auth.UserGroups) (string, *models.APIError) {
+	if name == "" {
+		return userGroups.DetermineDefaultNamespace(), nil
+	}
+
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return "", models.ErrInvalidInput.WithDetails(fmt.Sprintf("Namespace %q is not a valid name: %s", name, errs[0]))
+	}
+
+	if !userGroups.CanAccessNamespace(name) {
+		return "", models.ErrResourceAccessDenied.WithDetails(fmt.Sprintf("Namespace %s is not granted to your groups", name))
+	}
+
+	if minRole := config.Get().Console.MinNamespaceRole; minRole != "" && !userGroups.HasRole(name, minRole) {
+		return "", models.ErrInsufficientPermissions.WithDetails(fmt.Sprintf("Namespace %s requires role %s or higher", name, minRole))
+	}
+
+	return name, nil
+}
+
+// HandleListNamespaces 호출자가 사용할 수 있는 네임스페이스와 역할 목록 조회
+func (h *ConsoleHandler) HandleListNamespaces(c *gin.Context) {
+	// AuthMiddleware가 검증한 사용자 정보
+	identity, ok := requireIdentity(c)
+	if !ok {
+		return
+	}
+	userGroups := identity.UserGroups()
+	grants := userGroups.RoleGrants()
+	defaultNamespace := userGroups.DetermineDefaultNamespace()
+
+	namespaces := make([]models.NamespaceInfo, 0, len(grants.NamespaceRoles))
+	for _, namespace := range userGroups.Namespaces() {
+		namespaces = append(namespaces, models.NamespaceInfo{
+			Name:    namespace,
+			Role:    grants.NamespaceRoles[namespace],
+			Default: namespace == defaultNamespace,
+		})
+	}
+
+	utils.Response.Success(c, models.ListNamespacesResponse{
+		Namespaces:       namespaces,
+		Count:            len(namespaces),
+		DefaultNamespace: defaultNamespace,
+		ClusterAdmin:     grants.ClusterAdmin,
+	})
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"portal-backend/internal/config"
+	"portal-backend/internal/kubernetes"
+	"portal-backend/internal/logger"
+	"portal-backend/internal/models"
+	"portal-backend/internal/utils"
+)
+
+// 사용자 제한 초과 시 동작 모드
+const (
+	LimitModeReject = config.LimitModeReject // 에러 반환
+	LimitModeReuse  = config.LimitModeReuse  // 가장 최근 콘솔 재사용
+)
+
+// launchLocks 키별 콘솔 생성 잠금 (사용자, 네임스페이스, 전체)
+// 개수 확인과 생성 사이에 동시 요청이 끼어들어 제한을 넘지 않도록 직렬화
+type launchLocks struct {
+	mu    sync.Mutex
+	locks map[string]*launchLock
+}
+
+type launchLock struct {
+	mu      sync.Mutex
+	waiters int // 잠금을 기다리거나 보유한 요청 수 (0이 되면 맵에서 제거)
+}
+
+func newLaunchLocks() *launchLocks {
+	return &launchLocks{locks: make(map[string]*launchLock)}
+}
+
+// lock 키 잠금을 획득하고 해제 함수 반환
+func (l *launchLocks) lock(key string) func() {
+	l.mu.Lock()
+	keyLock, exists := l.locks[key]
+	if !exists {
+		keyLock = &launchLock{}
+		l.locks[key] = keyLock
+	}
+	keyLock.waiters++
+	l.mu.Unlock()
+
+	keyLock.mu.Lock()
+	return func() {
+		keyLock.mu.Unlock()
+
+		l.mu.Lock()
+		keyLock.waiters--
+		if keyLock.waiters == 0 {
+			delete(l.locks, key)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// capacityLockKey 네임스페이스/전체 개수 제한 확인에 필요한 잠금 키 (해당 제한이 없으면 빈 문자열)
+// 전체 제한이 있으면 모든 생성 요청을, 네임스페이스 제한만 있으면 같은 네임스페이스 요청만 직렬화
+// 잠금은 프로세스 내에서만 유효하므로 여러 레플리카에서는 제한이 최선 노력(best-effort)으로 적용됨
+func capacityLockKey(namespace string) string {
+	cfg := config.Get()
+	switch {
+	case cfg.Console.MaxTotal > 0:
+		return "*"
+	case cfg.Console.MaxPerNamespace > 0:
+		return "namespace/" + namespace
+	default:
+		return ""
+	}
+}
+
+// checkConsoleQuota 사용자별, 네임스페이스 그룹별, 전체 콘솔 개수 제한 확인
+// reuse 모드에서 사용자 제한에 걸리면 같은 클러스터와 네임스페이스로 실행된 기존 콘솔을 최신 순으로 함께 반환
+// (재사용할 수 있는 콘솔이 없으면 호출자가 에러를 그대로 응답)
+func (h *ConsoleHandler) checkConsoleQuota(userID, clusterName, namespace string) ([]*kubernetes.ConsoleResource, *models.APIError) {
+	cfg := config.Get()
+
+	resources, err := h.store.List()
+	if err != nil {
+		return nil, models.ErrKubernetesOperation.WithDetails("Operation: count console sessions").WithCause(err)
+	}
+
+	userResources := make([]*kubernetes.ConsoleResource, 0)
+	namespaceCount := 0
+	for _, resource := range resources {
+		if resource.UserID == userID {
+			userResources = append(userResources, resource)
+		}
+		if resource.TargetNamespace == namespace {
+			namespaceCount++
+		}
+	}
+
+	if cfg.Console.MaxPerUser > 0 && len(userResources) >= cfg.Console.MaxPerUser {
+		var candidates []*kubernetes.ConsoleResource
+		if cfg.Console.LimitMode == LimitModeReuse {
+			// 목록은 생성 시간 순이므로 뒤에서부터 모으면 최신 순
+			for i := len(userResources) - 1; i >= 0; i-- {
+				if userResources[i].Cluster == clusterName && userResources[i].TargetNamespace == namespace {
+					candidates = append(candidates, userResources[i])
+				}
+			}
+		}
+		return candidates, models.ErrConsoleUserLimitReached.WithDetails(
+			fmt.Sprintf("User %s already has %d console(s), limit is %d", userID, len(userResources), cfg.Console.MaxPerUser))
+	}
+
+	if cfg.Console.MaxPerNamespace > 0 && namespaceCount >= cfg.Console.MaxPerNamespace {
+		return nil, models.ErrConsoleCapacityExceeded.WithDetails(
+			fmt.Sprintf("Namespace %s already has %d console(s), limit is %d", namespace, namespaceCount, cfg.Console.MaxPerNamespace))
+	}
+
+	if cfg.Console.MaxTotal > 0 && len(resources) >= cfg.Console.MaxTotal {
+		return nil, models.ErrConsoleCapacityExceeded.WithDetails(
+			fmt.Sprintf("Cluster already has %d console(s), limit is %d", len(resources), cfg.Console.MaxTotal))
+	}
+
+	return nil, nil
+}
+
+// reuseConsole 후보 중 실패하지 않은 첫 콘솔을 실제 프로비저닝 단계와 함께 응답 (응답했으면 true)
+func (h *ConsoleHandler) reuseConsole(c *gin.Context, userID string, candidates []*kubernetes.ConsoleResource) bool {
+	for _, candidate := range candidates {
+		status, err := h.k8sClient.GetConsoleStatus(candidate.Namespace, candidate.ID)
+		if err != nil {
+			logger.WarnWithContext(c.Request.Context(), "Failed to get status of reusable console", map[string]any{
+				"user_id":     userID,
+				"resource_id": candidate.ID,
+				"error":       err.Error(),
+			})
+			continue
+		}
+		if status.Phase == kubernetes.PhaseFailed {
+			continue
+		}
+
+		launchStatus := models.ConsoleStatusProvisioning
+		if status.Ready {
+			launchStatus = models.ConsoleStatusReady
+		}
+
+		logger.InfoWithContext(c.Request.Context(), "Reusing existing web console", map[string]any{
+			"user_id":     userID,
+			"resource_id": candidate.ID,
+			"console_url": candidate.ConsoleURL,
+			"phase":       status.Phase,
+		})
+		utils.Response.SuccessWithMessage(c, "Existing web console reused", models.LaunchConsoleResponse{
+			URL:        candidate.ConsoleURL,
+			ResourceID: candidate.ID,
+			Reused:     true,
+			Status:     launchStatus,
+		})
+		return true
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"portal-backend/internal/config"
+	"portal-backend/internal/models"
+)
+
+func TestCheckConsoleQuota(t *testing.T) {
+	now := time.Now()
+	consoles := []testConsole{
+		{id: "alice-old", user: "alice", cluster: "local", namespace: "blue", created: now.Add(-30 * time.Minute)},
+		{id: "alice-red", user: "alice", cluster: "local", namespace: "red", created: now.Add(-20 * time.Minute)},
+		{id: "alice-new", user: "alice", cluster: "local", namespace: "blue", created: now.Add(-10 * time.Minute)},
+		{id: "bob", user: "bob", cluster: "local", namespace: "blue", created: now.Add(-5 * time.Minute)},
+	}
+	for i := range consoles {
+		consoles[i].expires = now.Add(time.Hour)
+	}
+	handler, _ := newTestConsoleHandler(t, consoles...)
+
+	tests := []struct {
+		name           string
+		console        func(*config.ConsoleConfig)
+		user           string
+		cluster        string
+		namespace      string
+		wantErr        *models.APIError
+		wantCandidates []string
+	}{
+		{
+			name:    "no limits",
+			console: func(*config.ConsoleConfig) {},
+			user:    "alice", cluster: "local", namespace: "blue",
+		},
+		{
+			name:    "under user limit",
+			console: func(c *config.ConsoleConfig) { c.MaxPerUser = 4 },
+			user:    "alice", cluster: "local", namespace: "blue",
+		},
+		{
+			name:    "user limit rejects",
+			console: func(c *config.ConsoleConfig) { c.MaxPerUser = 3; c.LimitMode = config.LimitModeReject },
+			user:    "alice", cluster: "local", namespace: "blue",
+			wantErr: models.ErrConsoleUserLimitReached,
+		},
+		{
+			name:    "user limit reuse returns matching consoles newest first",
+			console: func(c *config.ConsoleConfig) { c.MaxPerUser = 3; c.LimitMode = config.LimitModeReuse },
+			user:    "alice", cluster: "local", namespace: "blue",
+			wantErr:        models.ErrConsoleUserLimitReached,
+			wantCandidates: []string{"alice-new", "alice-old"},
+		},
+		{
+			name:    "user limit reuse without matching cluster",
+			console: func(c *config.ConsoleConfig) { c.MaxPerUser = 3; c.LimitMode = config.LimitModeReuse },
+			user:    "alice", cluster: "remote", namespace: "blue",
+			wantErr: models.ErrConsoleUserLimitReached,
+		},
+		{
+			name:    "namespace limit",
+			console: func(c *config.ConsoleConfig) { c.MaxPerNamespace = 3 },
+			user:    "carol", cluster: "local", namespace: "blue",
+			wantErr: models.ErrConsoleCapacityExceeded,
+		},
+		{
+			name:    "namespace limit counts only the target namespace",
+			console: func(c *config.ConsoleConfig) { c.MaxPerNamespace = 3 },
+			user:    "carol", cluster: "local", namespace: "red",
+		},
+		{
+			name:    "total limit",
+			console: func(c *config.ConsoleConfig) { c.MaxTotal = 4 },
+			user:    "carol", cluster: "local", namespace: "green",
+			wantErr: models.ErrConsoleCapacityExceeded,
+		},
+		{
+			name:    "under total limit",
+			console: func(c *config.ConsoleConfig) { c.MaxTotal = 5 },
+			user:    "carol", cluster: "local", namespace: "green",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConsoleConfig(t, tt.console)
+
+			candidates, apiErr := handler.checkConsoleQuota(tt.user, tt.cluster, tt.namespace)
+			switch {
+			case tt.wantErr == nil && apiErr != nil:
+				t.Fatalf("checkConsoleQuota() error = %v, want nil", apiErr)
+			case tt.wantErr != nil && (apiErr == nil || apiErr.Code != tt.wantErr.Code):
+				t.Fatalf("checkConsoleQuota() error = %v, want code %s", apiErr, tt.wantErr.Code)
+			}
+
+			ids := make([]string, 0, len(candidates))
+			for _, candidate := range candidates {
+				ids = append(ids, candidate.ID)
+			}
+			if !slices.Equal(ids, tt.wantCandidates) {
+				t.Errorf("candidates = %v, want %v", ids, tt.wantCandidates)
+			}
+		})
+	}
+}
+
+func TestCapacityLockKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		console func(*config.ConsoleConfig)
+		want    string
+	}{
+		{name: "no capacity limits", console: func(*config.ConsoleConfig) {}, want: ""},
+		{name: "namespace limit", console: func(c *config.ConsoleConfig) { c.MaxPerNamespace = 2 }, want: "namespace/blue"},
+		{name: "total limit wins", console: func(c *config.ConsoleConfig) { c.MaxPerNamespace = 2; c.MaxTotal = 10 }, want: "*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConsoleConfig(t, tt.console)
+			if got := capacityLockKey("blue"); got != tt.want {
+				t.Errorf("capacityLockKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
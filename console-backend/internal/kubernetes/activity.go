@@ -0,0 +1,105 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	portalConfig "portal-backend/internal/config"
+)
+
+// heartbeatURL 콘솔 Pod가 터미널 활동을 보고할 백엔드 URL 반환 (설정되지 않으면 빈 문자열)
+func heartbeatURL(resourceID string) string {
+	baseURL := strings.TrimSuffix(portalConfig.Get().Console.HeartbeatURL, "/")
+	if baseURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/api/console/%s/heartbeat", baseURL, resourceID)
+}
+
+// RecordConsoleActivity 세션 Deployment에 마지막 터미널 활동 시간 기록
+func (c *Client) RecordConsoleActivity(namespace, sessionID string) error {
+	ctx := context.Background()
+
+	deployments, err := c.ConsoleClientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("app=web-console,session=%s", sessionID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list deployments: %v", err)
+	}
+	if len(deployments.Items) == 0 {
+		return ErrSessionNotFound
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				AnnotationLastActivity: time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build activity patch: %v", err)
+	}
+
+	for _, deployment := range deployments.Items {
+		_, err := c.ConsoleClientset.AppsV1().Deployments(namespace).Patch(ctx, deployment.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to record activity on deployment %s: %v", deployment.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// SessionLastActivity 세션의 마지막 터미널 활동 시간 반환
+// 활동 기록이 없으면 세션 생성 시간을 사용
+func SessionLastActivity(meta metav1.ObjectMeta) time.Time {
+	for _, key := range []string{AnnotationLastActivity, AnnotationCreatedAt} {
+		if value, exists := meta.Annotations[key]; exists {
+			if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+				return parsed
+			}
+		}
+	}
+	return meta.CreationTimestamp.Time
+}
+
+// CleanupIdleResources 터미널 활동이 idleTimeout 이상 없는 세션 정리
+// 정리된 세션 ID 목록을 반환
+func (c *Client) CleanupIdleResources(namespace string, idleTimeout time.Duration) ([]string, error) {
+	ctx := context.Background()
+
+	deployments, err := c.ConsoleClientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app=web-console",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %v", err)
+	}
+
+	now := time.Now()
+	reaped := make([]string, 0)
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+
+		lastActivity := SessionLastActivity(deployment.ObjectMeta)
+		if now.Sub(lastActivity) < idleTimeout {
+			continue
+		}
+
+		message := fmt.Sprintf("Console session idle since %s (timeout %s)", lastActivity.UTC().Format(time.RFC3339), idleTimeout)
+		if err := c.reapSession(deployment, "ConsoleIdle", message); err != nil {
+			log.Printf("Failed to cleanup idle resources for deployment %s: %v", deployment.Name, err)
+			continue
+		}
+		reaped = append(reaped, deployment.Labels["session"])
+	}
+
+	return reaped, nil
+}
@@ -4,26 +4,41 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
+	"log"
 	"os"
+	"slices"
 	"text/template"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 
 	"portal-backend/internal/config"
 )
 
 // Client 쿠버네티스 클라이언트 래퍼 (다중 클러스터 지원)
 type Client struct {
-	Clientset       *kubernetes.Clientset // 로컬 클러스터 (A)
-	TargetClientset *kubernetes.Clientset // 타겟 클러스터 (B) - 웹 콘솔 생성용
+	Clientset       kubernetes.Interface // 로컬 클러스터 (A)
+	TargetClientset kubernetes.Interface // 타겟 클러스터 (B)
+
+	// ConsoleClientset 콘솔 Pod가 실행되는 클러스터 (CONSOLE_HOST_CLUSTER에 따라 A 또는 B)
+	// 콘솔 리소스의 생성, 조회, 삭제, 정리는 모두 이 클라이언트를 사용
+	ConsoleClientset kubernetes.Interface
+
+	recorder  record.EventRecorder // 콘솔 세션 이벤트 기록용
+	informers *consoleInformers    // 콘솔 오브젝트 준비 상태 감시용 공유 인포머
 }
 
 // NewClient 새로운 쿠버네티스 클라이언트 생성
 func NewClient() (*Client, error) {
+	cfg := config.Get()
+
 	// 클러스터 내부에서 실행되는 경우
-	config, err := rest.InClusterConfig()
+	restConfig, err := rest.InClusterConfig()
 	if err != nil {
 		// 클러스터 외부에서 실행되는 경우 (개발 환경)
 		kubeconfig := os.Getenv("KUBECONFIG")
@@ -31,13 +46,13 @@ func NewClient() (*Client, error) {
 			kubeconfig = os.Getenv("HOME") + "/.kube/config"
 		}
 
-		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+		restConfig, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to build kubeconfig: %v", err)
 		}
 	}
 
-	clientset, err := kubernetes.NewForConfig(config)
+	clientset, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes client: %v", err)
 	}
@@ -50,15 +65,47 @@ func NewClient() (*Client, error) {
 	if err == nil {
 		targetClientset, err = kubernetes.NewForConfig(targetConfig)
 		if err != nil {
-			// 타겟 클러스터 설정 실패 시 로컬 클러스터 사용
-			targetClientset = clientset
+			return nil, fmt.Errorf("failed to create target cluster client: %v", err)
 		}
+	} else if cfg.Kubernetes.ConsoleHostCluster == config.ClusterTarget {
+		return nil, fmt.Errorf("console host cluster is %s but target cluster is not configured: %v", config.ClusterTarget, err)
+	}
+
+	consoleClientset := clientset
+	if cfg.Kubernetes.ConsoleHostCluster == config.ClusterTarget {
+		consoleClientset = targetClientset
 	}
+	log.Printf("Console workloads are hosted on the %s cluster, kubeconfig points at the %s cluster",
+		cfg.Kubernetes.ConsoleHostCluster, cfg.Kubernetes.KubeconfigCluster)
+
+	return newClient(clientset, targetClientset, consoleClientset, cfg.Console.Namespace), nil
+}
+
+// NewClientWithClientset 이미 만든 clientset으로 클라이언트 생성 (로컬, 타겟, 콘솔 클러스터가 모두 같은 경우)
+// namespace는 콘솔 인포머가 감시할 네임스페이스
+func NewClientWithClientset(clientset kubernetes.Interface, namespace string) *Client {
+	return newClient(clientset, clientset, clientset, namespace)
+}
+
+// newClient 이벤트 기록기와 콘솔 인포머를 준비하여 클라이언트 생성
+func newClient(clientset, targetClientset, consoleClientset kubernetes.Interface, namespace string) *Client {
+	// 세션 정리 등 콘솔 수명 주기 이벤트를 콘솔이 실행되는 클러스터의 Event로 기록
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: consoleClientset.CoreV1().Events("")})
+	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "portal-backend"})
 
 	return &Client{
-		Clientset:       clientset,       // A 클러스터 (로컬)
-		TargetClientset: targetClientset, // B 클러스터 (타겟)
-	}, nil
+		Clientset:        clientset,       // A 클러스터 (로컬)
+		TargetClientset:  targetClientset, // B 클러스터 (타겟)
+		ConsoleClientset: consoleClientset,
+		recorder:         recorder,
+		informers:        newConsoleInformers(consoleClientset, namespace),
+	}
+}
+
+// Close 인포머 등 백그라운드 작업 종료
+func (c *Client) Close() {
+	c.informers.Stop()
 }
 
 // createTargetClusterConfig 타겟 클러스터 설정 생성
@@ -68,13 +115,18 @@ func createTargetClusterConfig() (*rest.Config, error) {
 	// 타겟 클러스터 설정이 있는 경우에만 연결 시도
 	if cfg.Kubernetes.TargetServer != "" {
 		config := &rest.Config{
-			Host: cfg.Kubernetes.TargetServer,
+			Host:        cfg.Kubernetes.TargetServer,
+			BearerToken: cfg.Kubernetes.TargetToken,
 		}
 
-		// CA 인증서 설정 (base64 인코딩된 데이터 사용)
+		// CA 인증서 설정 (base64 인코딩된 데이터를 디코딩하여 사용)
 		if cfg.Kubernetes.TargetCAData != "" {
+			caData, err := DecodeBase64ToCAData(cfg.Kubernetes.TargetCAData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode TARGET_CLUSTER_CA_CERT_DATA: %v", err)
+			}
 			config.TLSClientConfig = rest.TLSClientConfig{
-				CAData: []byte(cfg.Kubernetes.TargetCAData),
+				CAData: caData,
 			}
 		} else {
 			// CA 검증 비활성화 (개발/테스트 환경용)
@@ -89,49 +141,124 @@ func createTargetClusterConfig() (*rest.Config, error) {
 	return nil, fmt.Errorf("TARGET_CLUSTER_SERVER environment variable is required for multi-cluster setup")
 }
 
-// 템플릿 기반 kubeconfig
+// serviceAccountCAPath Pod에 마운트되는 서비스 계정 CA 인증서 경로
+const serviceAccountCAPath = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+
+// ClusterEndpoint 레지스트리 클러스터의 API 서버 URL과 CA 인증서(base64) 반환
+// 로컬 클러스터를 가리키면서 CA가 비어 있으면 포털 Pod의 서비스 계정 CA 사용 (동일 클러스터 내부 접근용)
+func ClusterEndpoint(cluster *config.ClusterConfig) (server, caData string) {
+	server, caData = cluster.Server, cluster.CAData
+	if caData == "" && server == config.Get().Kubernetes.LocalServer {
+		if encoded, err := EncodeCACertToBase64(serviceAccountCAPath); err == nil {
+			caData = encoded
+		}
+	}
+	return server, caData
+}
+
+// 템플릿 기반 kubeconfig (사용자가 역할을 가진 네임스페이스마다 컨텍스트 생성)
+// 사용자 이름 등 외부에서 온 값이 YAML 구조를 바꾸지 못하도록 모든 문자열 값은 큰따옴표로 이스케이프
 const KubeconfigTemplate = `
 apiVersion: v1
 kind: Config
 clusters:
-- name: kubernetes
+- name: {{printf "%q" .ClusterName}}
   cluster:
-    server: {{.ClusterServer}}
+    server: {{printf "%q" .ClusterServer}}
 {{- if .ClusterCAData }}
-    certificate-authority-data: {{.ClusterCAData}}
+    certificate-authority-data: {{printf "%q" .ClusterCAData}}
 {{- else }}
     insecure-skip-tls-verify: true
 {{- end }}
 contexts:
-- name: default-context
+{{- range .Namespaces }}
+- name: {{printf "%q" .}}
   context:
-    cluster: kubernetes
-    user: user
-    namespace: {{.DefaultNamespace}}
-current-context: default-context
+    cluster: {{printf "%q" $.ClusterName}}
+    user: {{printf "%q" $.UserName}}
+    namespace: {{printf "%q" .}}
+{{- end }}
+current-context: {{printf "%q" .DefaultNamespace}}
 users:
-- name: user
-  user: {}
+- name: {{printf "%q" .UserName}}
+  user:
+{{- with .Credential.Exec }}
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: {{printf "%q" .Command}}
+      args:
+{{- range .Args }}
+      - {{printf "%q" .}}
+{{- end }}
+      interactiveMode: IfAvailable
+      provideClusterInfo: false
+{{- else }}
+{{- if .Credential.TokenFile }}
+    tokenFile: {{printf "%q" .Credential.TokenFile}}
+{{- else if .Credential.Token }}
+    token: {{printf "%q" .Credential.Token}}
+{{- else }}
+    {}
+{{- end }}
+{{- end }}
 `
 
+// KubeconfigCredential kubeconfig 사용자 인증 정보 (Exec, TokenFile, Token 순으로 우선 적용)
+type KubeconfigCredential struct {
+	Token     string          // kubeconfig에 직접 포함할 토큰
+	TokenFile string          // 토큰 파일 경로 (파일이 갱신되면 kubectl이 새 토큰 사용)
+	Exec      *KubeconfigExec // exec 자격 증명 플러그인
+}
+
+// KubeconfigExec exec 자격 증명 플러그인 설정
+type KubeconfigExec struct {
+	Command string
+	Args    []string
+}
+
+// KubeconfigOptions kubeconfig 생성 옵션
+type KubeconfigOptions struct {
+	Cluster          *config.ClusterConfig // kubeconfig가 가리킬 클러스터
+	UserName         string                // kubeconfig 사용자 항목 이름
+	Namespaces       []string              // 컨텍스트를 만들 네임스페이스 목록
+	DefaultNamespace string                // current-context로 사용할 네임스페이스
+	Credential       KubeconfigCredential
+}
+
 type kubeconfigParams struct {
+	ClusterName      string
 	ClusterServer    string
 	ClusterCAData    string
+	UserName         string
+	Namespaces       []string
 	DefaultNamespace string
+	Credential       KubeconfigCredential
 }
 
-// GenerateUserKubeconfig 사용자의 기본 네임스페이스가 포함된 kubeconfig 생성
-func GenerateUserKubeconfig(defaultNamespace string) (string, error) {
-	cfg := config.Get()
+// GenerateUserKubeconfig 선택한 클러스터에 대해 네임스페이스별 컨텍스트가 포함된 kubeconfig 생성
+// 컨텍스트 이름은 네임스페이스 이름과 같으므로 kubectl config use-context <namespace>로 전환 가능
+func GenerateUserKubeconfig(opts KubeconfigOptions) (string, error) {
+	server, caData := ClusterEndpoint(opts.Cluster)
 
-	params := kubeconfigParams{
-		ClusterServer:    cfg.Kubernetes.TargetServer,
-		ClusterCAData:    cfg.Kubernetes.TargetCAData,
-		DefaultNamespace: defaultNamespace,
+	// 기본 네임스페이스는 항상 컨텍스트 목록에 포함
+	namespaces := opts.Namespaces
+	if !slices.Contains(namespaces, opts.DefaultNamespace) {
+		namespaces = append([]string{opts.DefaultNamespace}, namespaces...)
+	}
+
+	userName := opts.UserName
+	if userName == "" {
+		userName = "user"
 	}
 
-	if params.ClusterServer == "" {
-		params.ClusterServer = "https://kubernetes.default.svc"
+	params := kubeconfigParams{
+		ClusterName:      opts.Cluster.Name,
+		ClusterServer:    server,
+		ClusterCAData:    caData,
+		UserName:         userName,
+		Namespaces:       namespaces,
+		DefaultNamespace: opts.DefaultNamespace,
+		Credential:       opts.Credential,
 	}
 
 	tmpl, err := template.New("kubeconfig").Parse(KubeconfigTemplate)
@@ -0,0 +1,51 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ExtendConsoleSession 세션의 모든 오브젝트에 새로운 만료 시간 기록
+func (c *Client) ExtendConsoleSession(resource *ConsoleResource, expiresAt time.Time) error {
+	ctx := context.Background()
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				AnnotationExpiresAt: expiresAt.UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build expiry patch: %v", err)
+	}
+
+	// 정리 루틴은 Deployment의 어노테이션을 기준으로 하므로 Deployment를 먼저 갱신
+	_, err = c.ConsoleClientset.AppsV1().Deployments(resource.Namespace).Patch(ctx, resource.DeploymentName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to extend Deployment %s: %v", resource.DeploymentName, err)
+	}
+
+	_, err = c.ConsoleClientset.CoreV1().Services(resource.Namespace).Patch(ctx, resource.ServiceName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to extend Service %s: %v", resource.ServiceName, err)
+	}
+
+	_, err = c.ConsoleClientset.CoreV1().Secrets(resource.Namespace).Patch(ctx, resource.SecretName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to extend Secret %s: %v", resource.SecretName, err)
+	}
+
+	_, err = c.ConsoleClientset.NetworkingV1().Ingresses(resource.Namespace).Patch(ctx, resource.IngressName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to extend Ingress %s: %v", resource.IngressName, err)
+	}
+
+	resource.ExpiresAt = expiresAt
+	return nil
+}
@@ -0,0 +1,249 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// informerSyncTimeout 초기 캐시 동기화 대기 시간
+const informerSyncTimeout = 30 * time.Second
+
+// informerCacheWaitTimeout 새로 생성한 오브젝트가 캐시에 반영되기를 기다리는 시간
+const informerCacheWaitTimeout = 5 * time.Second
+
+// consoleInformers 콘솔 네임스페이스의 app=web-console 오브젝트를 감시하는 공유 인포머
+// 동시 실행 중인 콘솔 수와 관계없이 종류별 watch 하나만 유지하여 API 서버 부하를 일정하게 유지
+// 세션 목록, 상태 조회, 진행 상황 스트림이 모두 이 캐시를 사용
+type consoleInformers struct {
+	namespace    string
+	factory      informers.SharedInformerFactory
+	eventFactory informers.SharedInformerFactory // Warning 이벤트 전용 (이벤트에는 app 라벨이 없음)
+
+	deployments cache.SharedIndexInformer
+	pods        cache.SharedIndexInformer
+	services    cache.SharedIndexInformer
+	endpoints   cache.SharedIndexInformer // Endpoint는 Service의 라벨을 그대로 복사하므로 같은 셀렉터로 감시 가능
+	secrets     cache.SharedIndexInformer // 토큰이 캐시에 남지 않도록 메타데이터만 보관
+	pvcs        cache.SharedIndexInformer
+	ingresses   cache.SharedIndexInformer
+	events      cache.SharedIndexInformer
+
+	stopCh chan struct{}
+}
+
+// newConsoleInformers 인포머를 생성하고 시작한 뒤 초기 캐시 동기화를 대기
+func newConsoleInformers(clientset kubernetes.Interface, namespace string) *consoleInformers {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = "app=web-console"
+		}),
+	)
+
+	eventFactory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("type", corev1.EventTypeWarning).String()
+		}),
+	)
+
+	ci := &consoleInformers{
+		namespace:    namespace,
+		factory:      factory,
+		eventFactory: eventFactory,
+		deployments:  factory.Apps().V1().Deployments().Informer(),
+		pods:         factory.Core().V1().Pods().Informer(),
+		services:     factory.Core().V1().Services().Informer(),
+		endpoints:    factory.Core().V1().Endpoints().Informer(),
+		secrets:      factory.Core().V1().Secrets().Informer(),
+		pvcs:         factory.Core().V1().PersistentVolumeClaims().Informer(),
+		ingresses:    factory.Networking().V1().Ingresses().Informer(),
+		events:       eventFactory.Core().V1().Events().Informer(),
+		stopCh:       make(chan struct{}),
+	}
+
+	// Secret 데이터(kubeconfig, 토큰)는 필요할 때 API 서버에서 직접 읽음
+	if err := ci.secrets.SetTransform(stripSecretData); err != nil {
+		log.Printf("Warning: failed to set secret informer transform: %v", err)
+	}
+
+	factory.Start(ci.stopCh)
+	eventFactory.Start(ci.stopCh)
+
+	ctx, cancel := context.WithTimeout(context.Background(), informerSyncTimeout)
+	defer cancel()
+	for _, f := range []informers.SharedInformerFactory{factory, eventFactory} {
+		for informerType, synced := range f.WaitForCacheSync(ctx.Done()) {
+			if !synced {
+				// 동기화가 늦어지더라도 인포머는 백그라운드에서 계속 동기화되므로 경고만 출력
+				log.Printf("Warning: informer cache for %v not synced within %s", informerType, informerSyncTimeout)
+			}
+		}
+	}
+
+	log.Printf("Console informers started (namespace: %s, selector: app=web-console)", namespace)
+	return ci
+}
+
+// Stop 인포머 종료
+func (ci *consoleInformers) Stop() {
+	close(ci.stopCh)
+	ci.factory.Shutdown()
+	ci.eventFactory.Shutdown()
+}
+
+// stripSecretData 캐시에 넣기 전에 Secret 데이터 제거 (세션 구성에는 이름, 라벨, 어노테이션만 필요)
+func stripSecretData(obj any) (any, error) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return obj, nil
+	}
+	stripped := secret.DeepCopy()
+	stripped.Data = nil
+	stripped.StringData = nil
+	return stripped, nil
+}
+
+// checkNamespace 인포머가 감시하는 네임스페이스인지 확인
+func (ci *consoleInformers) checkNamespace(namespace string) error {
+	if namespace != ci.namespace {
+		return fmt.Errorf("namespace %s is not watched by console informers (watching %s)", namespace, ci.namespace)
+	}
+	return nil
+}
+
+// list 캐시에서 라벨 셀렉터와 일치하는 오브젝트 목록 조회
+func (ci *consoleInformers) list(informer cache.SharedIndexInformer, selector labels.Selector) []metav1.Object {
+	objects := make([]metav1.Object, 0)
+	for _, obj := range informer.GetIndexer().List() {
+		accessor, err := meta(obj)
+		if err != nil || !selector.Matches(labels.Set(accessor.GetLabels())) {
+			continue
+		}
+		objects = append(objects, accessor)
+	}
+	return objects
+}
+
+// waitFor 인포머에서 이름이 일치하는 오브젝트가 조건을 만족할 때까지 대기
+// 핸들러 등록 시 캐시에 있는 오브젝트가 Add 이벤트로 재생되므로 이미 준비된 경우에도 즉시 반환
+func (ci *consoleInformers) waitFor(informer cache.SharedIndexInformer, namespace, name string, timeout time.Duration, ready func(obj any) bool) error {
+	if err := ci.checkNamespace(namespace); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	signalled := false
+	check := func(obj any) {
+		accessor, err := meta(obj)
+		if err != nil || signalled {
+			return
+		}
+		if accessor.GetNamespace() != namespace || accessor.GetName() != name {
+			return
+		}
+		if ready(obj) {
+			signalled = true
+			close(done)
+		}
+	}
+
+	// 인포머는 등록된 핸들러를 하나의 고루틴에서 순서대로 호출하므로 signalled에 별도 잠금이 필요 없음
+	registration, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    check,
+		UpdateFunc: func(_, newObj any) { check(newObj) },
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register informer handler: %v", err)
+	}
+	defer func() {
+		if err := informer.RemoveEventHandler(registration); err != nil {
+			log.Printf("Failed to remove informer handler for %s/%s: %v", namespace, name, err)
+		}
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %s waiting for %s/%s", timeout, namespace, name)
+	}
+}
+
+// meta 인포머 이벤트 오브젝트에서 메타데이터 추출
+func meta(obj any) (metav1.Object, error) {
+	accessor, ok := obj.(metav1.Object)
+	if !ok {
+		return nil, fmt.Errorf("unexpected informer object type %T", obj)
+	}
+	return accessor, nil
+}
+
+// getDeployment 캐시에서 Deployment 조회
+func (ci *consoleInformers) getDeployment(namespace, name string) (*appsv1.Deployment, bool) {
+	obj, exists, err := ci.deployments.GetIndexer().GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		return nil, false
+	}
+	return obj.(*appsv1.Deployment), true
+}
+
+// getEndpoints 캐시에서 Endpoint 조회
+func (ci *consoleInformers) getEndpoints(namespace, name string) (*corev1.Endpoints, bool) {
+	obj, exists, err := ci.endpoints.GetIndexer().GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		return nil, false
+	}
+	return obj.(*corev1.Endpoints), true
+}
+
+// getIngress 캐시에서 Ingress 조회
+func (ci *consoleInformers) getIngress(namespace, name string) (*networkingv1.Ingress, bool) {
+	obj, exists, err := ci.ingresses.GetIndexer().GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		return nil, false
+	}
+	return obj.(*networkingv1.Ingress), true
+}
+
+// listSessionPods 캐시에서 세션 라벨이 일치하는 Pod 목록 조회
+func (ci *consoleInformers) listSessionPods(namespace, sessionID string) []corev1.Pod {
+	pods := make([]corev1.Pod, 0)
+	for _, obj := range ci.pods.GetIndexer().List() {
+		pod := obj.(*corev1.Pod)
+		if pod.Namespace == namespace && pod.Labels["session"] == sessionID {
+			pods = append(pods, *pod)
+		}
+	}
+	return pods
+}
+
+// isPodReady Pod가 Running 상태이고 Ready 조건을 만족하는지 확인
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// isIngressAssigned Ingress에 LoadBalancer IP/Host가 할당되었는지 확인
+func isIngressAssigned(ingress *networkingv1.Ingress) bool {
+	return len(ingress.Status.LoadBalancer.Ingress) > 0
+}
@@ -0,0 +1,259 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"portal-backend/internal/auth"
+	"portal-backend/internal/models"
+)
+
+// 로그인 저장소 Secret 라벨
+const (
+	loginStoreAppLabel = "portal-login"      // app 라벨 값 (콘솔 인포머의 app=web-console 셀렉터와 겹치지 않음)
+	loginKindLabel     = "portal-login/kind" // session 또는 pending
+	loginKindSession   = "session"
+	loginKindPending   = "pending"
+)
+
+// 로그인 저장소 Secret 어노테이션 키
+const (
+	annotationLoginExpiresAt      = "portal-login/expires-at"            // 세션 또는 로그인 요청 만료 시간 (정리 기준, RFC3339)
+	annotationLoginCreatedAt      = "portal-login/created-at"            // 세션 생성 시간 (RFC3339)
+	annotationLoginTokenExpiresAt = "portal-login/token-expires-at"      // 액세스 토큰 만료 시간 (RFC3339)
+	annotationLoginRefreshClaim   = "portal-login/refresh-claimed-until" // 토큰 갱신 선점 만료 시간 (RFC3339)
+)
+
+// 로그인 저장소 Secret 데이터 키
+const (
+	loginSessionIDKey    = "session-id"
+	loginUserIDKey       = "user-id"
+	loginStateKey        = "state"
+	loginAccessTokenKey  = "access-token"
+	loginIDTokenKey      = "id-token"
+	loginRefreshTokenKey = "refresh-token"
+	loginVerifierKey     = "code-verifier"
+	loginNonceKey        = "nonce"
+	loginRedirectKey     = "redirect"
+)
+
+// NewLoginStore 설정된 종류의 로그인 세션 저장소 생성
+func NewLoginStore(client *Client, storeType, namespace string) (auth.LoginStore, error) {
+	switch storeType {
+	case auth.LoginStoreMemory:
+		return auth.NewMemoryLoginStore(), nil
+	case auth.LoginStoreKubernetes, "":
+		return NewKubernetesLoginStore(client.Clientset, namespace), nil
+	default:
+		return nil, fmt.Errorf("unknown login session store type: %s", storeType)
+	}
+}
+
+// KubernetesLoginStore 포털 네임스페이스의 Secret에 로그인 세션과 로그인 요청을 저장하는 저장소
+// 세션마다 라벨이 붙은 Secret 하나를 사용하므로 재시작 후에도 로그인이 유지되고 모든 레플리카가 같은 세션을 봄
+// 토큰 갱신 선점과 state 일회성은 resourceVersion 조건부 Update/Delete로 보장
+type KubernetesLoginStore struct {
+	clientset kubernetes.Interface
+	namespace string
+}
+
+// NewKubernetesLoginStore 새로운 쿠버네티스 로그인 저장소 생성
+func NewKubernetesLoginStore(clientset kubernetes.Interface, namespace string) *KubernetesLoginStore {
+	return &KubernetesLoginStore{
+		clientset: clientset,
+		namespace: namespace,
+	}
+}
+
+// loginSecretName 세션 ID나 state로부터 Secret 이름 생성
+// 원래 값은 Secret 이름 규칙에 맞지 않을 수 있고 이름만으로 쿠키 값을 알 수 없도록 해시 사용
+func loginSecretName(kind, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("login-%s-%s", kind, hex.EncodeToString(sum[:])[:40])
+}
+
+// loginSecretMeta 로그인 저장소 Secret 메타데이터
+func (s *KubernetesLoginStore) loginSecretMeta(kind, key string, expiresAt time.Time) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      loginSecretName(kind, key),
+		Namespace: s.namespace,
+		Labels: map[string]string{
+			"app":          loginStoreAppLabel,
+			loginKindLabel: kind,
+		},
+		Annotations: map[string]string{
+			annotationLoginExpiresAt: expiresAt.UTC().Format(time.RFC3339),
+		},
+	}
+}
+
+// SavePending 로그인 요청 Secret 생성
+func (s *KubernetesLoginStore) SavePending(state string, login *auth.PendingLogin) error {
+	secret := &corev1.Secret{
+		ObjectMeta: s.loginSecretMeta(loginKindPending, state, login.ExpiresAt),
+		Type:       corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			loginVerifierKey: []byte(login.CodeVerifier),
+			loginNonceKey:    []byte(login.Nonce),
+			loginRedirectKey: []byte(login.Redirect),
+		},
+	}
+
+	if _, err := s.clientset.CoreV1().Secrets(s.namespace).Create(context.Background(), secret, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create login request secret: %v", err)
+	}
+	return nil
+}
+
+// TakePending 로그인 요청 Secret을 읽고 조건부로 삭제 (먼저 삭제한 레플리카만 성공)
+func (s *KubernetesLoginStore) TakePending(state string) (*auth.PendingLogin, error) {
+	ctx := context.Background()
+	secrets := s.clientset.CoreV1().Secrets(s.namespace)
+
+	secret, err := secrets.Get(ctx, loginSecretName(loginKindPending, state), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, auth.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login request secret: %v", err)
+	}
+
+	err = secrets.Delete(ctx, secret.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &secret.UID, ResourceVersion: &secret.ResourceVersion},
+	})
+	if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+		return nil, auth.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete login request secret: %v", err)
+	}
+
+	return &auth.PendingLogin{
+		CodeVerifier: string(secret.Data[loginVerifierKey]),
+		Nonce:        string(secret.Data[loginNonceKey]),
+		Redirect:     string(secret.Data[loginRedirectKey]),
+		ExpiresAt:    parseAnnotationTime(secret.Annotations, annotationLoginExpiresAt),
+	}, nil
+}
+
+// sessionSecret 세션을 Secret으로 변환
+func (s *KubernetesLoginStore) sessionSecret(stored *auth.StoredSession) *corev1.Secret {
+	session := &stored.Session
+	secret := &corev1.Secret{
+		ObjectMeta: s.loginSecretMeta(loginKindSession, session.SessionID, auth.SessionExpiry(session)),
+		Type:       corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			loginSessionIDKey:    []byte(session.SessionID),
+			loginUserIDKey:       []byte(session.UserID),
+			loginStateKey:        []byte(session.State),
+			loginAccessTokenKey:  []byte(session.AccessToken),
+			loginIDTokenKey:      []byte(session.IDToken),
+			loginRefreshTokenKey: []byte(session.RefreshToken),
+		},
+	}
+	secret.ResourceVersion = stored.Version
+	secret.Annotations[annotationLoginCreatedAt] = session.CreatedAt.UTC().Format(time.RFC3339Nano)
+	if !session.ExpiresAt.IsZero() {
+		secret.Annotations[annotationLoginTokenExpiresAt] = session.ExpiresAt.UTC().Format(time.RFC3339Nano)
+	}
+	if !stored.RefreshClaimedUntil.IsZero() {
+		secret.Annotations[annotationLoginRefreshClaim] = stored.RefreshClaimedUntil.UTC().Format(time.RFC3339Nano)
+	}
+	return secret
+}
+
+// storedSession Secret을 세션으로 변환
+func storedSession(secret *corev1.Secret) *auth.StoredSession {
+	return &auth.StoredSession{
+		Session: models.Session{
+			SessionID:    string(secret.Data[loginSessionIDKey]),
+			AccessToken:  string(secret.Data[loginAccessTokenKey]),
+			IDToken:      string(secret.Data[loginIDTokenKey]),
+			RefreshToken: string(secret.Data[loginRefreshTokenKey]),
+			UserID:       string(secret.Data[loginUserIDKey]),
+			ExpiresAt:    parseAnnotationTime(secret.Annotations, annotationLoginTokenExpiresAt),
+			State:        string(secret.Data[loginStateKey]),
+			CreatedAt:    parseAnnotationTime(secret.Annotations, annotationLoginCreatedAt),
+		},
+		RefreshClaimedUntil: parseAnnotationTime(secret.Annotations, annotationLoginRefreshClaim),
+		Version:             secret.ResourceVersion,
+	}
+}
+
+// CreateSession 세션 Secret 생성
+func (s *KubernetesLoginStore) CreateSession(session *models.Session) error {
+	secret := s.sessionSecret(&auth.StoredSession{Session: *session})
+	if _, err := s.clientset.CoreV1().Secrets(s.namespace).Create(context.Background(), secret, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create login session secret: %v", err)
+	}
+	return nil
+}
+
+// GetSession 세션 Secret 조회
+func (s *KubernetesLoginStore) GetSession(sessionID string) (*auth.StoredSession, error) {
+	secret, err := s.clientset.CoreV1().Secrets(s.namespace).Get(context.Background(), loginSecretName(loginKindSession, sessionID), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, auth.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login session secret: %v", err)
+	}
+	return storedSession(secret), nil
+}
+
+// UpdateSession resourceVersion 조건부 Update로 세션 Secret 갱신
+func (s *KubernetesLoginStore) UpdateSession(stored *auth.StoredSession) error {
+	updated, err := s.clientset.CoreV1().Secrets(s.namespace).Update(context.Background(), s.sessionSecret(stored), metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		return auth.ErrSessionNotFound
+	}
+	if apierrors.IsConflict(err) {
+		return auth.ErrSessionConflict
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update login session secret: %v", err)
+	}
+	stored.Version = updated.ResourceVersion
+	return nil
+}
+
+// DeleteSession 세션 Secret 삭제
+func (s *KubernetesLoginStore) DeleteSession(sessionID string) error {
+	err := s.clientset.CoreV1().Secrets(s.namespace).Delete(context.Background(), loginSecretName(loginKindSession, sessionID), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete login session secret: %v", err)
+	}
+	return nil
+}
+
+// Prune 만료 어노테이션이 지난 로그인 요청과 세션 Secret 삭제
+func (s *KubernetesLoginStore) Prune(now time.Time) error {
+	ctx := context.Background()
+	secrets := s.clientset.CoreV1().Secrets(s.namespace)
+
+	list, err := secrets.List(ctx, metav1.ListOptions{LabelSelector: "app=" + loginStoreAppLabel})
+	if err != nil {
+		return fmt.Errorf("failed to list login secrets: %v", err)
+	}
+
+	for i := range list.Items {
+		secret := &list.Items[i]
+		expiresAt := parseAnnotationTime(secret.Annotations, annotationLoginExpiresAt)
+		if expiresAt.IsZero() || now.Before(expiresAt) {
+			continue
+		}
+		if err := secrets.Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			log.Printf("Failed to delete expired login secret %s: %v", secret.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ProgressStep 콘솔 프로비저닝 진행 단계
+type ProgressStep string
+
+const (
+	StepPVCBound         ProgressStep = "pvc_bound"         // 히스토리 PVC 바인딩 완료
+	StepSecretCreated    ProgressStep = "secret_created"    // kubeconfig Secret 생성 완료
+	StepPodScheduled     ProgressStep = "pod_scheduled"     // Pod가 노드에 배치됨
+	StepContainerStarted ProgressStep = "container_started" // 콘솔 컨테이너 실행 시작
+	StepEndpointsReady   ProgressStep = "endpoints_ready"   // Service Endpoint 준비 완료
+	StepIngressAssigned  ProgressStep = "ingress_assigned"  // Ingress 주소 할당 완료
+	StepWarning          ProgressStep = "warning"           // Pod 관련 쿠버네티스 Warning 이벤트
+	StepReady            ProgressStep = "ready"             // 접속 가능 (스트림 종료)
+	StepFailed           ProgressStep = "failed"            // 프로비저닝 실패 (스트림 종료)
+)
+
+// ProgressEvent 프로비저닝 진행 이벤트
+type ProgressEvent struct {
+	Step    ProgressStep `json:"step"`
+	Object  string       `json:"object,omitempty"` // 관련 오브젝트 (kind/name)
+	Reason  string       `json:"reason,omitempty"`
+	Message string       `json:"message,omitempty"`
+	Time    time.Time    `json:"time"`
+}
+
+// IsTerminal 스트림을 종료해야 하는 이벤트인지 확인
+func (e ProgressEvent) IsTerminal() bool {
+	return e.Step == StepReady || e.Step == StepFailed
+}
+
+// WatchConsoleProgress 공유 인포머에 세션 오브젝트 핸들러를 등록하여 프로비저닝 진행 이벤트를 채널로 전달
+// 스트림마다 API watch를 열지 않으므로 동시에 진행 중인 실행 수와 관계없이 API 서버 부하가 일정함
+// ctx가 취소되면 핸들러를 제거하고 채널을 닫음
+func (c *Client) WatchConsoleProgress(ctx context.Context, namespace, sessionID string) (<-chan ProgressEvent, error) {
+	sessions, err := c.listConsoleSessions(namespace, fmt.Sprintf("app=web-console,session=%s", sessionID))
+	if err != nil {
+		return nil, err
+	}
+	resource, exists := sessions[sessionID]
+	if !exists || resource.DeploymentName == "" {
+		return nil, ErrSessionNotFound
+	}
+
+	bySession := func(obj any) bool {
+		accessor, err := meta(obj)
+		return err == nil && accessor.GetLabels()["session"] == sessionID
+	}
+	byName := func(name string) func(obj any) bool {
+		return func(obj any) bool {
+			accessor, err := meta(obj)
+			return err == nil && accessor.GetName() == name
+		}
+	}
+	tracker := newProgressTracker(resource, func() (*networkingv1.Ingress, bool) {
+		return c.informers.getIngress(namespace, resource.IngressName)
+	})
+
+	// 핸들러 등록 시 캐시에 있는 오브젝트가 Add 이벤트로 재생되므로 이미 진행된 단계도 스트림 초반에 전달됨
+	filters := map[cache.SharedIndexInformer]func(obj any) bool{
+		c.informers.pvcs:      byName(resource.PVCName),
+		c.informers.secrets:   bySession,
+		c.informers.pods:      bySession,
+		c.informers.endpoints: byName(resource.ServiceName),
+		c.informers.ingresses: bySession,
+		c.informers.events: func(obj any) bool {
+			event, ok := obj.(*corev1.Event)
+			return ok && tracker.isRelated(event)
+		},
+	}
+
+	raw := make(chan any)
+	forward := func(obj any) {
+		select {
+		case raw <- obj:
+		case <-ctx.Done():
+		}
+	}
+
+	registrations := make(map[cache.SharedIndexInformer]cache.ResourceEventHandlerRegistration, len(filters))
+	removeHandlers := func() {
+		for informer, registration := range registrations {
+			if err := informer.RemoveEventHandler(registration); err != nil {
+				log.Printf("Failed to remove progress handler for session %s: %v", sessionID, err)
+			}
+		}
+	}
+	for informer, filter := range filters {
+		registration, err := informer.AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: filter,
+			Handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    forward,
+				UpdateFunc: func(_, newObj any) { forward(newObj) },
+			},
+		})
+		if err != nil {
+			removeHandlers()
+			return nil, fmt.Errorf("failed to register progress handler: %v", err)
+		}
+		registrations[informer] = registration
+	}
+
+	out := make(chan ProgressEvent)
+	go func() {
+		defer close(out)
+		defer removeHandlers()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case obj := <-raw:
+				for _, event := range tracker.handle(obj) {
+					select {
+					case out <- event:
+					case <-ctx.Done():
+						return
+					}
+					if event.IsTerminal() {
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// progressTracker 이미 보낸 단계를 기억하여 같은 단계가 중복 전달되지 않도록 함
+type progressTracker struct {
+	resource *ConsoleResource
+	ingress  func() (*networkingv1.Ingress, bool) // 세션 Ingress 캐시 조회 (ready 전에 주소 할당 여부 확인)
+	sent     map[ProgressStep]bool
+	warnings map[string]int32 // Event UID별 마지막으로 보낸 발생 횟수
+}
+
+func newProgressTracker(resource *ConsoleResource, ingress func() (*networkingv1.Ingress, bool)) *progressTracker {
+	return &progressTracker{
+		resource: resource,
+		ingress:  ingress,
+		sent:     make(map[ProgressStep]bool),
+		warnings: make(map[string]int32),
+	}
+}
+
+// once 처음 도달한 단계만 이벤트로 변환
+func (t *progressTracker) once(step ProgressStep, object, reason, message string) []ProgressEvent {
+	if t.sent[step] {
+		return nil
+	}
+	t.sent[step] = true
+	return []ProgressEvent{{Step: step, Object: object, Reason: reason, Message: message, Time: time.Now()}}
+}
+
+// handle 인포머 Add/Update 이벤트의 오브젝트를 진행 이벤트로 변환
+func (t *progressTracker) handle(object any) []ProgressEvent {
+	switch obj := object.(type) {
+	case *corev1.PersistentVolumeClaim:
+		if obj.Status.Phase == corev1.ClaimBound {
+			return t.once(StepPVCBound, "PersistentVolumeClaim/"+obj.Name, "", fmt.Sprintf("bound to volume %s", obj.Spec.VolumeName))
+		}
+
+	case *corev1.Secret:
+		return t.once(StepSecretCreated, "Secret/"+obj.Name, "", "")
+
+	case *corev1.Pod:
+		return t.handlePod(obj)
+
+	case *corev1.Endpoints:
+		if hasReadyEndpoints(obj) {
+			events := t.once(StepEndpointsReady, "Endpoints/"+obj.Name, "", "")
+
+			// Ingress 주소 할당은 ready를 막지 않음 (컨트롤러에 따라 주소를 보고하지 않을 수 있음)
+			// 이미 할당된 경우에만 ready 전에 전달하여 스트림 종료 전에 빠지지 않도록 함
+			if ingress, exists := t.ingress(); exists {
+				events = append(events, t.ingressAssigned(ingress)...)
+			}
+			return append(events, t.once(StepReady, "Endpoints/"+obj.Name, "", t.resource.ConsoleURL)...)
+		}
+
+	case *networkingv1.Ingress:
+		return t.ingressAssigned(obj)
+
+	case *corev1.Event:
+		return t.handleWarning(obj)
+	}
+
+	return nil
+}
+
+// ingressAssigned Ingress에 주소가 할당되었으면 ingress_assigned 단계로 변환
+func (t *progressTracker) ingressAssigned(ingress *networkingv1.Ingress) []ProgressEvent {
+	if !isIngressAssigned(ingress) {
+		return nil
+	}
+	address := ingress.Status.LoadBalancer.Ingress[0].IP
+	if address == "" {
+		address = ingress.Status.LoadBalancer.Ingress[0].Hostname
+	}
+	return t.once(StepIngressAssigned, "Ingress/"+ingress.Name, "", fmt.Sprintf("address %s", address))
+}
+
+// handlePod Pod 상태로부터 스케줄링, 컨테이너 시작, 실패 단계 판단
+func (t *progressTracker) handlePod(pod *corev1.Pod) []ProgressEvent {
+	if pod.DeletionTimestamp != nil {
+		return nil
+	}
+
+	object := "Pod/" + pod.Name
+	events := make([]ProgressEvent, 0)
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionTrue {
+			events = append(events, t.once(StepPodScheduled, object, "", fmt.Sprintf("assigned to node %s", pod.Spec.NodeName))...)
+		}
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.State.Running != nil {
+			events = append(events, t.once(StepContainerStarted, object, "", fmt.Sprintf("container %s started", containerStatus.Name))...)
+		}
+	}
+
+	if phase, reason, message := podPhase(pod); phase == PhaseFailed {
+		events = append(events, t.once(StepFailed, object, reason, message)...)
+	}
+
+	return events
+}
+
+// isRelated 세션 Pod/ReplicaSet 또는 히스토리 PVC에 대한 Warning 이벤트인지 확인
+func (t *progressTracker) isRelated(event *corev1.Event) bool {
+	involved := event.InvolvedObject
+	return strings.HasPrefix(involved.Name, t.resource.DeploymentName+"-") ||
+		(involved.Kind == "PersistentVolumeClaim" && involved.Name == t.resource.PVCName)
+}
+
+// handleWarning 세션과 관련된 Warning 이벤트만 전달
+func (t *progressTracker) handleWarning(event *corev1.Event) []ProgressEvent {
+	if !t.isRelated(event) {
+		return nil
+	}
+	involved := event.InvolvedObject
+
+	// 같은 Event가 반복되면 발생 횟수가 늘어난 경우에만 다시 전달
+	if count, seen := t.warnings[string(event.UID)]; seen && count >= event.Count {
+		return nil
+	}
+	t.warnings[string(event.UID)] = event.Count
+
+	return []ProgressEvent{{
+		Step:    StepWarning,
+		Object:  involved.Kind + "/" + involved.Name,
+		Reason:  event.Reason,
+		Message: event.Message,
+		Time:    time.Now(),
+	}}
+}
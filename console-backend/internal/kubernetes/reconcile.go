@@ -0,0 +1,90 @@
+package kubernetes
+
+import (
+	"log"
+	"time"
+)
+
+// PartialSessionGracePeriod 생성 중인 세션을 부분 세션으로 오인하지 않기 위한 유예 시간
+const PartialSessionGracePeriod = 5 * time.Minute
+
+// PartialSession 일부 리소스가 누락된 세션 정보
+type PartialSession struct {
+	Resource  *ConsoleResource `json:"resource"`
+	Missing   []string         `json:"missing"`   // 누락된 리소스 종류 (deployment, service, secret, ingress)
+	Collected bool             `json:"collected"` // 가비지 컬렉션 수행 여부
+}
+
+// ReconcileResult 클러스터 라벨 기반 세션 재구성 결과
+type ReconcileResult struct {
+	Complete []*ConsoleResource `json:"complete"` // 모든 리소스가 존재하는 세션
+	Partial  []*PartialSession  `json:"partial"`  // 일부 리소스가 누락된 세션
+}
+
+// MissingComponents 세션에서 누락된 리소스 종류 반환
+func (r *ConsoleResource) MissingComponents() []string {
+	missing := make([]string, 0)
+	if r.DeploymentName == "" {
+		missing = append(missing, "deployment")
+	}
+	if r.ServiceName == "" {
+		missing = append(missing, "service")
+	}
+	if r.SecretName == "" {
+		missing = append(missing, "secret")
+	}
+	if r.IngressName == "" {
+		missing = append(missing, "ingress")
+	}
+	return missing
+}
+
+// IsComplete 세션의 모든 리소스가 존재하는지 확인
+func (r *ConsoleResource) IsComplete() bool {
+	return len(r.MissingComponents()) == 0
+}
+
+// ReconcileConsoleSessions app, user, session 라벨이 붙은 오브젝트로부터 세션을 재구성
+// 완전한 세션은 결과로 반환하고, 유예 시간이 지난 부분 세션은 남은 리소스를 삭제
+func (c *Client) ReconcileConsoleSessions(namespace string, gracePeriod time.Duration) (*ReconcileResult, error) {
+	sessions, err := c.listConsoleSessions(namespace, "app=web-console")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReconcileResult{
+		Complete: make([]*ConsoleResource, 0),
+		Partial:  make([]*PartialSession, 0),
+	}
+	cutoff := time.Now().Add(-gracePeriod)
+
+	for sessionID, resource := range sessions {
+		missing := resource.MissingComponents()
+		if len(missing) == 0 {
+			result.Complete = append(result.Complete, resource)
+			continue
+		}
+
+		partial := &PartialSession{
+			Resource: resource,
+			Missing:  missing,
+		}
+		result.Partial = append(result.Partial, partial)
+
+		// 아직 생성 중일 수 있는 세션은 건너뜀
+		if resource.CreatedAt.After(cutoff) {
+			log.Printf("Skipping recently created partial session %s (missing: %v)", sessionID, missing)
+			continue
+		}
+
+		log.Printf("Garbage-collecting partial session %s for user %s (missing: %v)", sessionID, resource.UserID, missing)
+		if err := c.cleanupResourcesByLabels(namespace, map[string]string{"session": sessionID}); err != nil {
+			log.Printf("Failed to garbage-collect partial session %s: %v", sessionID, err)
+			continue
+		}
+		partial.Collected = true
+	}
+
+	sortByCreatedAt(result.Complete)
+	return result, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"maps"
 	"os"
 	"time"
 
@@ -13,11 +14,13 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
 
 	"portal-backend/internal/auth"
 	portalConfig "portal-backend/internal/config"
+	"portal-backend/internal/logger"
 )
 
 // ConsoleResource 웹 콘솔 리소스 정보
@@ -32,8 +35,34 @@ type ConsoleResource struct {
 	Namespace      string    `json:"namespace"`
 	ConsoleURL     string    `json:"console_url"`
 	CreatedAt      time.Time `json:"created_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+
+	TargetNamespace string `json:"target_namespace"` // 콘솔의 기본 작업 네임스페이스
+	Cluster         string `json:"cluster"`          // kubeconfig가 가리키는 레지스트리 클러스터 이름
+
+	TokenExpiresAt    time.Time `json:"token_expires_at"`              // 콘솔 쿠버네티스 토큰 만료 시간
+	TokenRefreshError string    `json:"token_refresh_error,omitempty"` // 마지막 토큰 갱신 실패 사유
 }
 
+// 세션 수명 관리를 위한 어노테이션 키
+const (
+	AnnotationCreatedAt    = "web-console/created-at"    // 세션 생성 시간 (RFC3339)
+	AnnotationExpiresAt    = "web-console/expires-at"    // 세션 만료 시간 (RFC3339)
+	AnnotationLastActivity = "web-console/last-activity" // 마지막 터미널 활동 시간 (RFC3339)
+
+	AnnotationTargetNamespace = "web-console/target-namespace" // 콘솔의 기본 작업 네임스페이스
+)
+
+// 콘솔 Pod 내부의 세션 파일 경로 (세션 Secret의 키와 파일 이름이 같음)
+// 디렉토리 전체를 마운트하므로 Secret이 갱신되면 파일도 함께 갱신됨 (subPath 마운트는 갱신되지 않음)
+const (
+	consoleSessionDir        = "/var/run/console"
+	kubeconfigKey            = "config"
+	kubeTokenKey             = "token"
+	heartbeatTokenKey        = "heartbeat-token"
+	heartbeatIntervalSeconds = 60
+)
+
 // ConsoleConfig 웹 콘솔 설정
 type ConsoleConfig struct {
 	Namespace     string
@@ -86,13 +115,25 @@ func GetDefaultConfig() *ConsoleConfig {
 	}
 }
 
-// CreateConsoleResources 웹 콘솔 리소스 생성 (defaultNamespace 인자 추가)
-func (c *Client) CreateConsoleResources(userID, idToken, refreshToken, defaultNamespace string) (*ConsoleResource, error) {
+// CreateConsoleResources 웹 콘솔 리소스 생성
+// kubeconfig는 선택한 클러스터에 userGroups의 네임스페이스마다 컨텍스트를 만들고 defaultNamespace를 current-context로 사용
+// token의 refresh token은 세션 Secret에만 보관되어 토큰 갱신 루틴(RefreshConsoleTokens)에서 사용됨
+func (c *Client) CreateConsoleResources(userID string, userGroups *auth.UserGroups, token *auth.TokenExchangeResponse, defaultNamespace string, cluster *portalConfig.ClusterConfig) (*ConsoleResource, error) {
 	config := GetDefaultConfig()
 	// 전체 UUID + timestamp로 고유성 보장
 	fullUUID := uuid.New().String()
 	timestamp := fmt.Sprintf("%d", time.Now().Unix())
 	resourceID := fmt.Sprintf("%s-%s", fullUUID, timestamp)
+	createdAt := time.Now()
+	expiresAt := createdAt.Add(time.Duration(config.TTLSeconds) * time.Second)
+
+	// 모든 세션 오브젝트에 생성/만료 시간 기록
+	sessionAnnotations := map[string]string{
+		AnnotationCreatedAt: createdAt.UTC().Format(time.RFC3339),
+		AnnotationExpiresAt: expiresAt.UTC().Format(time.RFC3339),
+
+		AnnotationTargetNamespace: defaultNamespace,
+	}
 
 	consoleResource := &ConsoleResource{
 		ID:             resourceID,
@@ -103,7 +144,11 @@ func (c *Client) CreateConsoleResources(userID, idToken, refreshToken, defaultNa
 		IngressName:    fmt.Sprintf("console-ingress-%s-%s", userID, fullUUID),
 		PVCName:        fmt.Sprintf("history-%s", userID), // 사용자별 히스토리는 공유
 		Namespace:      config.Namespace,
-		CreatedAt:      time.Now(),
+		CreatedAt:      createdAt,
+		ExpiresAt:      expiresAt,
+
+		TargetNamespace: defaultNamespace,
+		Cluster:         cluster.Name,
 	}
 
 	ctx := context.Background()
@@ -133,36 +178,58 @@ func (c *Client) CreateConsoleResources(userID, idToken, refreshToken, defaultNa
 	}
 
 	// PVC가 존재하지 않으면 생성 (사용자별 히스토리는 공유)
-	_, err := c.Clientset.CoreV1().PersistentVolumeClaims(consoleResource.Namespace).Get(ctx, consoleResource.PVCName, metav1.GetOptions{})
+	_, err := c.ConsoleClientset.CoreV1().PersistentVolumeClaims(consoleResource.Namespace).Get(ctx, consoleResource.PVCName, metav1.GetOptions{})
 	if err != nil {
-		_, err = c.Clientset.CoreV1().PersistentVolumeClaims(consoleResource.Namespace).Create(ctx, pvc, metav1.CreateOptions{})
+		_, err = c.ConsoleClientset.CoreV1().PersistentVolumeClaims(consoleResource.Namespace).Create(ctx, pvc, metav1.CreateOptions{})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create PVC: %v", err)
 		}
 	}
 
-	// 2. Secret 생성 (kubeconfig 보안 저장)
-	kubeconfig, errGen := GenerateUserKubeconfig(defaultNamespace)
+	// 2. Secret 생성 (kubeconfig와 쿠버네티스 토큰 보안 저장, 토큰은 파일로만 전달)
+	kubeconfig, errGen := GenerateUserKubeconfig(KubeconfigOptions{
+		Cluster:          cluster,
+		UserName:         userID,
+		Namespaces:       userGroups.Namespaces(),
+		DefaultNamespace: defaultNamespace,
+		Credential:       KubeconfigCredential{TokenFile: consoleSessionDir + "/" + kubeTokenKey},
+	})
 	if errGen != nil {
 		return nil, fmt.Errorf("failed to generate user-specific kubeconfig: %v", errGen)
 	}
+	clusterServer, clusterCAData := ClusterEndpoint(cluster)
+
+	// Secret에는 세션 어노테이션과 함께 토큰 만료 시간 기록 (갱신 루틴이 참조)
+	secretAnnotations := maps.Clone(sessionAnnotations)
+	maps.Copy(secretAnnotations, tokenAnnotations(token, createdAt))
+	consoleResource.TokenExpiresAt = parseAnnotationTime(secretAnnotations, AnnotationTokenExpiresAt)
+
+	secretData := map[string][]byte{
+		kubeconfigKey:     []byte(kubeconfig),
+		kubeTokenKey:      []byte(token.AccessToken),
+		heartbeatTokenKey: []byte(auth.GenerateHeartbeatToken(resourceID)),
+	}
+	if token.RefreshToken != "" {
+		secretData[refreshTokenKey] = []byte(token.RefreshToken)
+	}
+
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      consoleResource.SecretName,
-			Namespace: consoleResource.Namespace,
+			Name:        consoleResource.SecretName,
+			Namespace:   consoleResource.Namespace,
+			Annotations: secretAnnotations,
 			Labels: map[string]string{
 				"app":     "web-console",
 				"user":    userID,
 				"session": resourceID,
+				"cluster": cluster.Name,
 			},
 		},
 		Type: corev1.SecretTypeOpaque,
-		Data: map[string][]byte{
-			"config": []byte(kubeconfig),
-		},
+		Data: secretData,
 	}
 
-	_, err = c.Clientset.CoreV1().Secrets(consoleResource.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+	_, err = c.ConsoleClientset.CoreV1().Secrets(consoleResource.Namespace).Create(ctx, secret, metav1.CreateOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Secret: %v", err)
 	}
@@ -170,12 +237,14 @@ func (c *Client) CreateConsoleResources(userID, idToken, refreshToken, defaultNa
 	// 3. Deployment 생성
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      consoleResource.DeploymentName,
-			Namespace: consoleResource.Namespace,
+			Name:        consoleResource.DeploymentName,
+			Namespace:   consoleResource.Namespace,
+			Annotations: sessionAnnotations,
 			Labels: map[string]string{
 				"app":     "web-console",
 				"user":    userID,
 				"session": resourceID,
+				"cluster": cluster.Name,
 			},
 		},
 		Spec: appsv1.DeploymentSpec{
@@ -193,6 +262,7 @@ func (c *Client) CreateConsoleResources(userID, idToken, refreshToken, defaultNa
 						"app":     "web-console",
 						"user":    userID,
 						"session": resourceID,
+						"cluster": cluster.Name,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -220,32 +290,46 @@ func (c *Client) CreateConsoleResources(userID, idToken, refreshToken, defaultNa
 								echo "Initializing web terminal environment..."
 								
 								# Check kubeconfig is available
-								if [ -f /home/user/.kube/config ]; then
+								if [ -f "$KUBECONFIG" ]; then
 									echo "Kubeconfig found, checking connectivity..."
 									kubectl version --client || echo "kubectl client ready"
 								else
 									echo "Warning: kubeconfig not found"
 								fi
 								
+								# Report terminal activity while a ttyd client is connected
+								if [ -n "$CONSOLE_HEARTBEAT_URL" ]; then
+									(
+										while true; do
+											sleep %d
+											if netstat -tn 2>/dev/null | grep -q ":%d .*ESTABLISHED"; then
+												curl -fsS -m 5 -X POST -H "X-Console-Token: $(cat "$CONSOLE_HEARTBEAT_TOKEN_FILE")" "$CONSOLE_HEARTBEAT_URL" >/dev/null 2>&1 || true
+											fi
+										done
+									) &
+								fi
+								
 								# Start ttyd service with base path
 								echo "Starting ttyd service with base path..."
-								exec ttyd --port 8080 --writable --max-clients 1 --base-path /%s/%s bash
-								`, userID, fullUUID),
+								exec ttyd --port %d --writable --max-clients 1 --base-path /%s/%s bash
+								`, heartbeatIntervalSeconds, config.ContainerPort, config.ContainerPort, userID, fullUUID),
 							},
 							Env: []corev1.EnvVar{
-								{Name: "KUBECONFIG", Value: "/home/user/.kube/config"},
-								{Name: "K8S_TOKEN", Value: idToken},
-								{Name: "K8S_SERVER", Value: os.Getenv("TARGET_CLUSTER_SERVER")},
-								{Name: "K8S_CA_DATA", Value: os.Getenv("TARGET_CLUSTER_CA_CERT_DATA")},
+								{Name: "KUBECONFIG", Value: consoleSessionDir + "/" + kubeconfigKey},
+								{Name: "K8S_TOKEN_FILE", Value: consoleSessionDir + "/" + kubeTokenKey},
+								{Name: "K8S_SERVER", Value: clusterServer},
+								{Name: "K8S_CA_DATA", Value: clusterCAData},
 								{Name: "USER_ID", Value: userID},
 								{Name: "DEFAULT_NAMESPACE", Value: defaultNamespace},
-								{Name: "USER_ROLES", Value: getUserRoles(userID, idToken)},
+								{Name: "USER_ROLES", Value: getUserRoles(userGroups)},
+								{Name: "NAMESPACE_ROLES", Value: userGroups.NamespaceRoles().ToJSON()},
+								{Name: "CONSOLE_HEARTBEAT_URL", Value: heartbeatURL(resourceID)},
+								{Name: "CONSOLE_HEARTBEAT_TOKEN_FILE", Value: consoleSessionDir + "/" + heartbeatTokenKey},
 							},
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									Name:      "kubeconfig",
-									MountPath: "/home/user/.kube/config",
-									SubPath:   "config",
+									MountPath: consoleSessionDir,
 									ReadOnly:  true,
 								},
 								{
@@ -274,8 +358,16 @@ func (c *Client) CreateConsoleResources(userID, idToken, refreshToken, defaultNa
 									SecretName: consoleResource.SecretName,
 									Items: []corev1.KeyToPath{
 										{
-											Key:  "config",
-											Path: "config",
+											Key:  kubeconfigKey,
+											Path: kubeconfigKey,
+										},
+										{
+											Key:  kubeTokenKey,
+											Path: kubeTokenKey,
+										},
+										{
+											Key:  heartbeatTokenKey,
+											Path: heartbeatTokenKey,
 										},
 									},
 								},
@@ -296,22 +388,24 @@ func (c *Client) CreateConsoleResources(userID, idToken, refreshToken, defaultNa
 		},
 	}
 
-	_, err = c.Clientset.AppsV1().Deployments(consoleResource.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
+	_, err = c.ConsoleClientset.AppsV1().Deployments(consoleResource.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
 	if err != nil {
 		// Secret 정리
-		c.Clientset.CoreV1().Secrets(consoleResource.Namespace).Delete(ctx, consoleResource.SecretName, metav1.DeleteOptions{})
+		c.ConsoleClientset.CoreV1().Secrets(consoleResource.Namespace).Delete(ctx, consoleResource.SecretName, metav1.DeleteOptions{})
 		return nil, fmt.Errorf("failed to create Deployment: %v", err)
 	}
 
 	// 4. Service 생성
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      consoleResource.ServiceName,
-			Namespace: consoleResource.Namespace,
+			Name:        consoleResource.ServiceName,
+			Namespace:   consoleResource.Namespace,
+			Annotations: sessionAnnotations,
 			Labels: map[string]string{
 				"app":     "web-console",
 				"user":    userID,
 				"session": resourceID,
+				"cluster": cluster.Name,
 			},
 		},
 		Spec: corev1.ServiceSpec{
@@ -330,11 +424,11 @@ func (c *Client) CreateConsoleResources(userID, idToken, refreshToken, defaultNa
 		},
 	}
 
-	_, err = c.Clientset.CoreV1().Services(consoleResource.Namespace).Create(ctx, service, metav1.CreateOptions{})
+	_, err = c.ConsoleClientset.CoreV1().Services(consoleResource.Namespace).Create(ctx, service, metav1.CreateOptions{})
 	if err != nil {
 		// Deployment와 Secret 정리
-		c.Clientset.AppsV1().Deployments(consoleResource.Namespace).Delete(ctx, consoleResource.DeploymentName, metav1.DeleteOptions{})
-		c.Clientset.CoreV1().Secrets(consoleResource.Namespace).Delete(ctx, consoleResource.SecretName, metav1.DeleteOptions{})
+		c.ConsoleClientset.AppsV1().Deployments(consoleResource.Namespace).Delete(ctx, consoleResource.DeploymentName, metav1.DeleteOptions{})
+		c.ConsoleClientset.CoreV1().Secrets(consoleResource.Namespace).Delete(ctx, consoleResource.SecretName, metav1.DeleteOptions{})
 		return nil, fmt.Errorf("failed to create Service: %v", err)
 	}
 
@@ -344,12 +438,14 @@ func (c *Client) CreateConsoleResources(userID, idToken, refreshToken, defaultNa
 
 	ingress := &networkingv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      consoleResource.IngressName,
-			Namespace: consoleResource.Namespace,
+			Name:        consoleResource.IngressName,
+			Namespace:   consoleResource.Namespace,
+			Annotations: sessionAnnotations,
 			Labels: map[string]string{
 				"app":     "web-console",
 				"user":    userID,
 				"session": resourceID,
+				"cluster": cluster.Name,
 			},
 		},
 		Spec: networkingv1.IngressSpec{
@@ -386,145 +482,122 @@ func (c *Client) CreateConsoleResources(userID, idToken, refreshToken, defaultNa
 		},
 	}
 
-	_, err = c.Clientset.NetworkingV1().Ingresses(consoleResource.Namespace).Create(ctx, ingress, metav1.CreateOptions{})
+	_, err = c.ConsoleClientset.NetworkingV1().Ingresses(consoleResource.Namespace).Create(ctx, ingress, metav1.CreateOptions{})
 	if err != nil {
 		// Deployment, Service, Secret 정리
-		c.Clientset.AppsV1().Deployments(consoleResource.Namespace).Delete(ctx, consoleResource.DeploymentName, metav1.DeleteOptions{})
-		c.Clientset.CoreV1().Services(consoleResource.Namespace).Delete(ctx, consoleResource.ServiceName, metav1.DeleteOptions{})
-		c.Clientset.CoreV1().Secrets(consoleResource.Namespace).Delete(ctx, consoleResource.SecretName, metav1.DeleteOptions{})
+		c.ConsoleClientset.AppsV1().Deployments(consoleResource.Namespace).Delete(ctx, consoleResource.DeploymentName, metav1.DeleteOptions{})
+		c.ConsoleClientset.CoreV1().Services(consoleResource.Namespace).Delete(ctx, consoleResource.ServiceName, metav1.DeleteOptions{})
+		c.ConsoleClientset.CoreV1().Secrets(consoleResource.Namespace).Delete(ctx, consoleResource.SecretName, metav1.DeleteOptions{})
 		return nil, fmt.Errorf("failed to create Ingress: %v", err)
 	}
 
-	// Deployment가 준비될 때까지 대기
-	log.Printf("Waiting for Deployment %s to be ready...", consoleResource.DeploymentName)
-	err = c.WaitForDeploymentReady(consoleResource.DeploymentName, consoleResource.Namespace, 60*time.Second)
-	if err != nil {
-		log.Printf("Deployment %s not ready after timeout: %v. Cleaning up resources...", consoleResource.DeploymentName, err)
-		// Deployment가 준비되지 않으면 생성된 리소스 정리
-		c.DeleteConsoleResources(consoleResource)
-		return nil, fmt.Errorf("deployment not ready after timeout: %w", err)
-	}
-	log.Printf("Deployment %s is ready", consoleResource.DeploymentName)
-
-	// Service Endpoint가 준비될 때까지 대기
-	log.Printf("Waiting for Service %s endpoints to be ready...", consoleResource.ServiceName)
-	err = c.WaitForServiceReady(consoleResource.ServiceName, consoleResource.Namespace, 30*time.Second)
-	if err != nil {
-		log.Printf("Service %s endpoints not ready after timeout: %v. Cleaning up resources...", consoleResource.ServiceName, err)
-		// Service Endpoint가 준비되지 않으면 생성된 리소스 정리
-		c.DeleteConsoleResources(consoleResource)
-		return nil, fmt.Errorf("service endpoints not ready after timeout: %w", err)
-	}
-	log.Printf("Service %s is ready with endpoints", consoleResource.ServiceName)
-
-	// Ingress가 준비될 때까지 대기
-	log.Printf("Waiting for Ingress %s to be ready...", consoleResource.IngressName)
-	err = c.WaitForIngressReady(consoleResource.IngressName, consoleResource.Namespace, 30*time.Second)
-	if err != nil {
-		log.Printf("Warning: Ingress %s not fully ready after timeout: %v. Proceeding anyway as it may need more time.", consoleResource.IngressName, err)
-		// Ingress는 경고만 출력하고 계속 진행 (백그라운드에서 준비될 수 있음)
-	} else {
-		log.Printf("Ingress %s is ready", consoleResource.IngressName)
-	}
-
 	// 콘솔 URL 생성 (사용자별 고유 경로)
 	baseURL := portalConfig.Get().Console.BaseURL
 	consoleResource.ConsoleURL = fmt.Sprintf("https://%s/%s/%s", baseURL, userID, fullUUID)
 
-	log.Printf("Console resources created successfully. URL: %s", consoleResource.ConsoleURL)
+	// 세션 목록, 개수 제한, 상태 조회가 인포머 캐시를 사용하므로 생성한 오브젝트가 캐시에 반영될 때까지 대기
+	c.waitForCached(c.informers.deployments, consoleResource.Namespace, consoleResource.DeploymentName)
+	c.waitForCached(c.informers.ingresses, consoleResource.Namespace, consoleResource.IngressName)
+
+	// 준비 상태 확인은 백그라운드에서 진행하고 즉시 반환 (진행 상황은 GetConsoleStatus로 조회)
+	go c.waitForConsoleReady(consoleResource)
+
+	log.Printf("Console resources created, provisioning in background. URL: %s", consoleResource.ConsoleURL)
 	return consoleResource, nil
 }
 
-// WaitForDeploymentReady Deployment가 준비될 때까지 대기
+// waitForCached 생성한 오브젝트가 인포머 캐시에 나타날 때까지 잠시 대기 (시간 초과 시 경고만 출력)
+func (c *Client) waitForCached(informer cache.SharedIndexInformer, namespace, name string) {
+	if err := c.informers.waitFor(informer, namespace, name, informerCacheWaitTimeout, func(any) bool { return true }); err != nil {
+		log.Printf("Warning: %s/%s not yet visible in informer cache: %v", namespace, name, err)
+	}
+}
+
+// WaitForDeploymentReady Deployment가 준비될 때까지 대기 (공유 인포머 이벤트 기반)
 func (c *Client) WaitForDeploymentReady(deploymentName, namespace string, timeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+	return c.informers.waitFor(c.informers.deployments, namespace, deploymentName, timeout, func(obj any) bool {
+		deployment, ok := obj.(*appsv1.Deployment)
+		return ok && IsDeploymentReady(deployment)
+	})
+}
 
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		deployment, err := c.Clientset.AppsV1().Deployments(namespace).Get(ctx, deploymentName, metav1.GetOptions{})
-		if err != nil {
-			return false, err
-		}
+// IsDeploymentReady Deployment의 모든 레플리카가 Ready인지 확인
+func IsDeploymentReady(deployment *appsv1.Deployment) bool {
+	return deployment.Status.ReadyReplicas > 0 && deployment.Status.ReadyReplicas == deployment.Status.Replicas
+}
 
-		// Deployment가 Ready인지 확인
-		if deployment.Status.ReadyReplicas > 0 && deployment.Status.ReadyReplicas == deployment.Status.Replicas {
-			return true, nil
+// FindReadyConsole 사용자와 클러스터 라벨로 준비된 콘솔을 찾아 가장 최근 세션 반환 (없으면 nil, 공유 인포머 캐시 사용)
+// targetNamespace가 비어 있지 않으면 그 네임스페이스로 실행된 콘솔 중에서만 선택
+func (c *Client) FindReadyConsole(userID, clusterName, targetNamespace, namespace string) (*ConsoleResource, error) {
+	if err := c.informers.checkNamespace(namespace); err != nil {
+		return nil, err
+	}
+	selector, err := labels.Parse(fmt.Sprintf("app=web-console,user=%s,cluster=%s", userID, clusterName))
+	if err != nil {
+		return nil, fmt.Errorf("invalid console label selector: %v", err)
+	}
+
+	var newest *appsv1.Deployment
+	for _, obj := range c.informers.list(c.informers.deployments, selector) {
+		deployment := obj.(*appsv1.Deployment)
+		if deployment.DeletionTimestamp != nil || !IsDeploymentReady(deployment) {
+			continue
+		}
+		if targetNamespace != "" && deployment.Annotations[AnnotationTargetNamespace] != targetNamespace {
+			continue
 		}
+		if newest == nil || deployment.CreationTimestamp.After(newest.CreationTimestamp.Time) {
+			newest = deployment
+		}
+	}
+	if newest == nil {
+		return nil, nil
+	}
 
-		return false, nil
-	})
+	sessionID := newest.Labels["session"]
+	sessions, err := c.listConsoleSessions(namespace, fmt.Sprintf("app=web-console,session=%s", sessionID))
+	if err != nil {
+		return nil, err
+	}
+
+	// 접속에 필요한 Service, Ingress까지 모두 있는 세션만 재사용
+	resource, exists := sessions[sessionID]
+	if !exists || !resource.IsComplete() || resource.ConsoleURL == "" {
+		return nil, nil
+	}
+	return resource, nil
 }
 
 // WaitForPodReady Pod가 준비될 때까지 대기 (기존 호환성 유지)
 func (c *Client) WaitForPodReady(podName, namespace string, timeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		pod, err := c.TargetClientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
-		if err != nil {
-			return false, err
-		}
-
-		// Pod가 Running 상태이고 모든 컨테이너가 Ready인지 확인
-		if pod.Status.Phase == corev1.PodRunning {
-			for _, condition := range pod.Status.Conditions {
-				if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
-					return true, nil
-				}
-			}
-		}
-
-		return false, nil
+	return c.informers.waitFor(c.informers.pods, namespace, podName, timeout, func(obj any) bool {
+		pod, ok := obj.(*corev1.Pod)
+		return ok && isPodReady(pod)
 	})
 }
 
 // WaitForServiceReady Service의 Endpoint가 준비될 때까지 대기
 func (c *Client) WaitForServiceReady(serviceName, namespace string, timeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		// Endpoint 조회 (Service와 동일한 이름)
-		endpoints, err := c.Clientset.CoreV1().Endpoints(namespace).Get(ctx, serviceName, metav1.GetOptions{})
-		if err != nil {
-			return false, err
+	// Endpoint는 Service와 동일한 이름으로 생성됨
+	return c.informers.waitFor(c.informers.endpoints, namespace, serviceName, timeout, func(obj any) bool {
+		endpoints, ok := obj.(*corev1.Endpoints)
+		if !ok || !hasReadyEndpoints(endpoints) {
+			return false
 		}
-
-		// Endpoint에 실제 Pod IP가 등록되었는지 확인
-		if len(endpoints.Subsets) > 0 {
-			for _, subset := range endpoints.Subsets {
-				if len(subset.Addresses) > 0 {
-					log.Printf("Service %s has %d ready endpoint(s)", serviceName, len(subset.Addresses))
-					return true, nil
-				}
-			}
-		}
-
-		log.Printf("Waiting for Service %s to have ready endpoints...", serviceName)
-		return false, nil
+		log.Printf("Service %s has ready endpoint(s)", serviceName)
+		return true
 	})
 }
 
 // WaitForIngressReady Ingress가 준비될 때까지 대기
 func (c *Client) WaitForIngressReady(ingressName, namespace string, timeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		ingress, err := c.Clientset.NetworkingV1().Ingresses(namespace).Get(ctx, ingressName, metav1.GetOptions{})
-		if err != nil {
-			return false, err
+	return c.informers.waitFor(c.informers.ingresses, namespace, ingressName, timeout, func(obj any) bool {
+		ingress, ok := obj.(*networkingv1.Ingress)
+		if !ok || !isIngressAssigned(ingress) {
+			return false
 		}
-
-		// Ingress에 LoadBalancer IP/Host가 할당되었는지 확인
-		if len(ingress.Status.LoadBalancer.Ingress) > 0 {
-			log.Printf("Ingress %s is ready with LoadBalancer: %v", ingressName, ingress.Status.LoadBalancer.Ingress)
-			return true, nil
-		}
-
-		log.Printf("Waiting for Ingress %s to be ready...", ingressName)
-		return false, nil
+		log.Printf("Ingress %s is ready with LoadBalancer: %v", ingressName, ingress.Status.LoadBalancer.Ingress)
+		return true
 	})
 }
 
@@ -534,7 +607,7 @@ func (c *Client) DeleteConsoleResources(resource *ConsoleResource) error {
 	deletePolicy := metav1.DeletePropagationForeground
 
 	// Service 삭제
-	err := c.Clientset.CoreV1().Services(resource.Namespace).Delete(ctx, resource.ServiceName, metav1.DeleteOptions{
+	err := c.ConsoleClientset.CoreV1().Services(resource.Namespace).Delete(ctx, resource.ServiceName, metav1.DeleteOptions{
 		PropagationPolicy: &deletePolicy,
 	})
 	if err != nil {
@@ -542,7 +615,7 @@ func (c *Client) DeleteConsoleResources(resource *ConsoleResource) error {
 	}
 
 	// Deployment 삭제
-	err = c.Clientset.AppsV1().Deployments(resource.Namespace).Delete(ctx, resource.DeploymentName, metav1.DeleteOptions{
+	err = c.ConsoleClientset.AppsV1().Deployments(resource.Namespace).Delete(ctx, resource.DeploymentName, metav1.DeleteOptions{
 		PropagationPolicy: &deletePolicy,
 	})
 	if err != nil {
@@ -550,13 +623,13 @@ func (c *Client) DeleteConsoleResources(resource *ConsoleResource) error {
 	}
 
 	// Secret 삭제
-	err = c.Clientset.CoreV1().Secrets(resource.Namespace).Delete(ctx, resource.SecretName, metav1.DeleteOptions{})
+	err = c.ConsoleClientset.CoreV1().Secrets(resource.Namespace).Delete(ctx, resource.SecretName, metav1.DeleteOptions{})
 	if err != nil {
 		log.Printf("Failed to delete Secret %s: %v", resource.SecretName, err)
 	}
 
 	// Ingress 삭제
-	err = c.Clientset.NetworkingV1().Ingresses(resource.Namespace).Delete(ctx, resource.IngressName, metav1.DeleteOptions{})
+	err = c.ConsoleClientset.NetworkingV1().Ingresses(resource.Namespace).Delete(ctx, resource.IngressName, metav1.DeleteOptions{})
 	if err != nil {
 		log.Printf("Failed to delete Ingress %s: %v", resource.IngressName, err)
 	}
@@ -567,30 +640,97 @@ func (c *Client) DeleteConsoleResources(resource *ConsoleResource) error {
 }
 
 // CleanupExpiredResources 만료된 리소스 정리
-func (c *Client) CleanupExpiredResources(namespace string) error {
+// TTL이 지난 세션은 상태와 관계없이 삭제하고, 정리된 세션 ID 목록을 반환
+func (c *Client) CleanupExpiredResources(namespace string) ([]string, error) {
 	ctx := context.Background()
+	ttl := time.Duration(GetDefaultConfig().TTLSeconds) * time.Second
 
 	// 웹 콘솔 관련 리소스들을 라벨로 찾아서 정리
 	labelSelector := "app=web-console"
 
 	// 만료된 Deployment 찾기 및 삭제
-	deployments, err := c.Clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
+	deployments, err := c.ConsoleClientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: labelSelector,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to list deployments: %v", err)
+		return nil, fmt.Errorf("failed to list deployments: %v", err)
 	}
 
-	for _, deployment := range deployments.Items {
-		// Deployment가 실패했거나 오래된 경우 관련 리소스 정리
-		if deployment.Status.ReadyReplicas == 0 && deployment.Status.Replicas > 0 {
-			if err := c.cleanupResourcesByLabels(namespace, deployment.Labels); err != nil {
+	now := time.Now()
+	reaped := make([]string, 0)
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+
+		// TTL이 지난 세션은 정상 동작 여부와 관계없이 정리
+		expiresAt := SessionExpiresAt(deployment.ObjectMeta, ttl)
+		if now.After(expiresAt) {
+			message := fmt.Sprintf("Console session expired at %s", expiresAt.UTC().Format(time.RFC3339))
+			if err := c.reapSession(deployment, "ConsoleExpired", message); err != nil {
 				log.Printf("Failed to cleanup resources for deployment %s: %v", deployment.Name, err)
+				continue
 			}
+			reaped = append(reaped, deployment.Labels["session"])
+			continue
+		}
+
+		// 프로비저닝에 실패했거나 제한 시간이 지나도 준비되지 않은 Deployment 정리
+		failed := deployment.Annotations[AnnotationPhase] == string(PhaseFailed)
+		stuck := deployment.Status.ReadyReplicas == 0 && deployment.Status.Replicas > 0 &&
+			now.Sub(deployment.CreationTimestamp.Time) > ProvisioningTimeout
+		if failed || stuck {
+			message := fmt.Sprintf("Console session failed to become ready: %s", deployment.Annotations[AnnotationFailureReason])
+			if err := c.reapSession(deployment, "ConsoleFailed", message); err != nil {
+				log.Printf("Failed to cleanup resources for deployment %s: %v", deployment.Name, err)
+				continue
+			}
+			reaped = append(reaped, deployment.Labels["session"])
 		}
 	}
 
-	return nil
+	return reaped, nil
+}
+
+// SessionExpiresAt 세션 오브젝트의 만료 시간 반환
+// 어노테이션이 없는 이전 세션은 생성 시간 + TTL로 계산
+func SessionExpiresAt(meta metav1.ObjectMeta, ttl time.Duration) time.Time {
+	if value, exists := meta.Annotations[AnnotationExpiresAt]; exists {
+		if expiresAt, err := time.Parse(time.RFC3339, value); err == nil {
+			return expiresAt
+		}
+	}
+
+	createdAt := meta.CreationTimestamp.Time
+	if value, exists := meta.Annotations[AnnotationCreatedAt]; exists {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			createdAt = parsed
+		}
+	}
+	return createdAt.Add(ttl)
+}
+
+// reapSession 세션 정리 기록 후 세션 라벨이 붙은 리소스 삭제
+func (c *Client) reapSession(deployment *appsv1.Deployment, reason, message string) error {
+	c.recordSessionReaped(deployment, reason, message)
+	return c.cleanupResourcesByLabels(deployment.Namespace, deployment.Labels)
+}
+
+// recordSessionReaped 세션 정리 시 구조화된 로그와 쿠버네티스 이벤트 기록
+func (c *Client) recordSessionReaped(deployment *appsv1.Deployment, reason, message string) {
+	logger.InfoWithContext(context.TODO(), "Reaping console session", map[string]any{
+		"reason":        reason,
+		"message":       message,
+		"resource_id":   deployment.Labels["session"],
+		"user_id":       deployment.Labels["user"],
+		"namespace":     deployment.Namespace,
+		"deployment":    deployment.Name,
+		"created_at":    deployment.Annotations[AnnotationCreatedAt],
+		"expires_at":    deployment.Annotations[AnnotationExpiresAt],
+		"last_activity": deployment.Annotations[AnnotationLastActivity],
+	})
+
+	if c.recorder != nil {
+		c.recorder.Event(deployment, corev1.EventTypeNormal, reason, message)
+	}
 }
 
 // cleanupResourcesByLabels 라벨로 관련 리소스 정리
@@ -601,42 +741,42 @@ func (c *Client) cleanupResourcesByLabels(namespace string, labels map[string]st
 		labelSelector := fmt.Sprintf("session=%s", sessionID)
 
 		// Service 삭제
-		services, err := c.Clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{
+		services, err := c.ConsoleClientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{
 			LabelSelector: labelSelector,
 		})
 		if err == nil {
 			for _, svc := range services.Items {
-				c.Clientset.CoreV1().Services(namespace).Delete(ctx, svc.Name, metav1.DeleteOptions{})
+				c.ConsoleClientset.CoreV1().Services(namespace).Delete(ctx, svc.Name, metav1.DeleteOptions{})
 			}
 		}
 
 		// Secret 삭제
-		secrets, err := c.Clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		secrets, err := c.ConsoleClientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
 			LabelSelector: labelSelector,
 		})
 		if err == nil {
 			for _, secret := range secrets.Items {
-				c.Clientset.CoreV1().Secrets(namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{})
+				c.ConsoleClientset.CoreV1().Secrets(namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{})
 			}
 		}
 
 		// Deployment 삭제
-		deployments, err := c.Clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
+		deployments, err := c.ConsoleClientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
 			LabelSelector: labelSelector,
 		})
 		if err == nil {
 			for _, deployment := range deployments.Items {
-				c.Clientset.AppsV1().Deployments(namespace).Delete(ctx, deployment.Name, metav1.DeleteOptions{})
+				c.ConsoleClientset.AppsV1().Deployments(namespace).Delete(ctx, deployment.Name, metav1.DeleteOptions{})
 			}
 		}
 
 		// Ingress 삭제
-		ingresses, err := c.Clientset.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{
+		ingresses, err := c.ConsoleClientset.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{
 			LabelSelector: labelSelector,
 		})
 		if err == nil {
 			for _, ingress := range ingresses.Items {
-				c.Clientset.NetworkingV1().Ingresses(namespace).Delete(ctx, ingress.Name, metav1.DeleteOptions{})
+				c.ConsoleClientset.NetworkingV1().Ingresses(namespace).Delete(ctx, ingress.Name, metav1.DeleteOptions{})
 			}
 		}
 	}
@@ -655,14 +795,14 @@ func (c *Client) DeleteUserResources(userID, namespace string) error {
 	log.Printf("Deleting all resources for user: %s", userID)
 
 	// 1. Service 삭제
-	services, err := c.Clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{
+	services, err := c.ConsoleClientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: userLabelSelector,
 	})
 	if err != nil {
 		log.Printf("Failed to list services for user %s: %v", userID, err)
 	} else {
 		for _, service := range services.Items {
-			err = c.Clientset.CoreV1().Services(namespace).Delete(ctx, service.Name, metav1.DeleteOptions{
+			err = c.ConsoleClientset.CoreV1().Services(namespace).Delete(ctx, service.Name, metav1.DeleteOptions{
 				PropagationPolicy: &deletePolicy,
 			})
 			if err != nil {
@@ -674,14 +814,14 @@ func (c *Client) DeleteUserResources(userID, namespace string) error {
 	}
 
 	// 2. Deployment 삭제
-	deployments, err := c.Clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
+	deployments, err := c.ConsoleClientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: userLabelSelector,
 	})
 	if err != nil {
 		log.Printf("Failed to list deployments for user %s: %v", userID, err)
 	} else {
 		for _, deployment := range deployments.Items {
-			err = c.Clientset.AppsV1().Deployments(namespace).Delete(ctx, deployment.Name, metav1.DeleteOptions{
+			err = c.ConsoleClientset.AppsV1().Deployments(namespace).Delete(ctx, deployment.Name, metav1.DeleteOptions{
 				PropagationPolicy: &deletePolicy,
 			})
 			if err != nil {
@@ -693,14 +833,14 @@ func (c *Client) DeleteUserResources(userID, namespace string) error {
 	}
 
 	// 3. Secret 삭제
-	secrets, err := c.Clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+	secrets, err := c.ConsoleClientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: userLabelSelector,
 	})
 	if err != nil {
 		log.Printf("Failed to list secrets for user %s: %v", userID, err)
 	} else {
 		for _, secret := range secrets.Items {
-			err = c.Clientset.CoreV1().Secrets(namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{})
+			err = c.ConsoleClientset.CoreV1().Secrets(namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{})
 			if err != nil {
 				log.Printf("Failed to delete Secret %s: %v", secret.Name, err)
 			} else {
@@ -710,14 +850,14 @@ func (c *Client) DeleteUserResources(userID, namespace string) error {
 	}
 
 	// 4. Ingress 삭제
-	ingresses, err := c.Clientset.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{
+	ingresses, err := c.ConsoleClientset.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: userLabelSelector,
 	})
 	if err != nil {
 		log.Printf("Failed to list ingresses for user %s: %v", userID, err)
 	} else {
 		for _, ingress := range ingresses.Items {
-			err = c.Clientset.NetworkingV1().Ingresses(namespace).Delete(ctx, ingress.Name, metav1.DeleteOptions{})
+			err = c.ConsoleClientset.NetworkingV1().Ingresses(namespace).Delete(ctx, ingress.Name, metav1.DeleteOptions{})
 			if err != nil {
 				log.Printf("Failed to delete Ingress %s: %v", ingress.Name, err)
 			} else {
@@ -740,123 +880,7 @@ func parseInt32(s string) int32 {
 }
 
 // getUserRoles 사용자의 역할 정보를 문자열로 반환
-func getUserRoles(userID, idToken string) string {
-	userGroups, err := auth.ExtractUserGroups(idToken)
-	if err != nil {
-		return "unknown"
-	}
-
-	if len(userGroups.Groups) == 0 {
-		return "user"
-	}
-
-	// 역할 우선순위에 따라 정렬
-	roles := make([]string, 0)
-
-	// cluster-admins가 있으면 최우선
-	if contains(userGroups.Groups, "cluster-admins") {
-		roles = append(roles, "cluster-admin")
-	}
-
-	// 네임스페이스별 역할 추가
-	namespaceRoles := make(map[string]string)
-	for _, group := range userGroups.Groups {
-		parts := splitN(group, "-", 2)
-		if len(parts) == 2 {
-			namespace := parts[0]
-			role := parts[1]
-
-			// 이미 더 높은 권한이 있으면 건너뛰기
-			if existingRole, exists := namespaceRoles[namespace]; exists {
-				if getRolePriority(role) < getRolePriority(existingRole) {
-					namespaceRoles[namespace] = role
-				}
-			} else {
-				namespaceRoles[namespace] = role
-			}
-		}
-	}
-
-	// 네임스페이스별 역할을 정렬하여 추가
-	for namespace, role := range namespaceRoles {
-		roles = append(roles, fmt.Sprintf("%s-%s", namespace, role))
-	}
-
-	// 역할이 없으면 기본값
-	if len(roles) == 0 {
-		return "user"
-	}
-
-	// "/"로 구분하여 반환
-	return join(roles, "/")
-}
-
-// contains 문자열 슬라이스에 특정 문자열이 포함되어 있는지 확인
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
-}
-
-// splitN 문자열을 구분자로 분할 (Go 1.18 이전 버전 호환)
-func splitN(s, sep string, n int) []string {
-	if n <= 0 {
-		return nil
-	}
-
-	result := make([]string, 0, n)
-	start := 0
-	for i := 0; i < n-1; i++ {
-		idx := index(s[start:], sep)
-		if idx == -1 {
-			break
-		}
-		result = append(result, s[start:start+idx])
-		start = start + idx + len(sep)
-	}
-	result = append(result, s[start:])
-	return result
-}
-
-// index 문자열에서 하위 문자열의 인덱스 반환
-func index(s, substr string) int {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return i
-		}
-	}
-	return -1
-}
-
-// join 문자열 슬라이스를 구분자로 연결
-func join(slice []string, sep string) string {
-	if len(slice) == 0 {
-		return ""
-	}
-	if len(slice) == 1 {
-		return slice[0]
-	}
-
-	result := slice[0]
-	for i := 1; i < len(slice); i++ {
-		result += sep + slice[i]
-	}
-	return result
-}
-
-// getRolePriority 역할의 우선순위 반환 (낮을수록 높음)
-func getRolePriority(role string) int {
-	switch role {
-	case "admin":
-		return 1
-	case "developer":
-		return 2
-	case "viewer":
-		return 3
-	default:
-		return 99
-	}
+// 기본 네임스페이스 결정과 같은 그룹 매핑 규칙을 사용하므로 표시되는 역할과 네임스페이스가 항상 일치
+func getUserRoles(userGroups *auth.UserGroups) string {
+	return userGroups.RoleSummary()
 }
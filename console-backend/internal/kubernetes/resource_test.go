@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+const testNamespace = "console"
+
+// testSessionObjects 세션 라벨이 붙은 Deployment, Service, Secret (annotations는 세션 오브젝트 공통)
+func testSessionObjects(sessionID string, created time.Time, annotations map[string]string, status appsv1.DeploymentStatus) []runtime.Object {
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         testNamespace,
+			Labels:            map[string]string{"app": "web-console", "user": "alice", "session": sessionID},
+			Annotations:       annotations,
+			CreationTimestamp: metav1.NewTime(created),
+		}
+	}
+	return []runtime.Object{
+		&appsv1.Deployment{ObjectMeta: meta("console-" + sessionID), Status: status},
+		&corev1.Service{ObjectMeta: meta("console-" + sessionID)},
+		&corev1.Secret{ObjectMeta: meta("console-" + sessionID)},
+	}
+}
+
+func TestCleanupExpiredResources(t *testing.T) {
+	now := time.Now()
+	rfc := func(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+	running := appsv1.DeploymentStatus{Replicas: 1, ReadyReplicas: 1}
+	pending := appsv1.DeploymentStatus{Replicas: 1}
+
+	var objects []runtime.Object
+	objects = append(objects, testSessionObjects("expired", now.Add(-2*time.Hour),
+		map[string]string{AnnotationCreatedAt: rfc(now.Add(-2 * time.Hour)), AnnotationExpiresAt: rfc(now.Add(-time.Minute))}, running)...)
+	objects = append(objects, testSessionObjects("active", now.Add(-time.Hour),
+		map[string]string{AnnotationCreatedAt: rfc(now.Add(-time.Hour)), AnnotationExpiresAt: rfc(now.Add(time.Hour))}, running)...)
+	// 어노테이션이 없는 이전 세션은 생성 시간 + TTL(기본 1시간)로 만료 판단
+	objects = append(objects, testSessionObjects("legacy", now.Add(-3*time.Hour), nil, running)...)
+	objects = append(objects, testSessionObjects("failed", now.Add(-time.Minute),
+		map[string]string{AnnotationExpiresAt: rfc(now.Add(time.Hour)), AnnotationPhase: string(PhaseFailed), AnnotationFailureReason: "ImagePullBackOff"}, pending)...)
+	objects = append(objects, testSessionObjects("stuck", now.Add(-ProvisioningTimeout-time.Minute),
+		map[string]string{AnnotationExpiresAt: rfc(now.Add(time.Hour))}, pending)...)
+	objects = append(objects, testSessionObjects("provisioning", now.Add(-time.Minute),
+		map[string]string{AnnotationExpiresAt: rfc(now.Add(time.Hour))}, pending)...)
+
+	clientset := fake.NewSimpleClientset(objects...)
+	recorder := record.NewFakeRecorder(10)
+	client := &Client{ConsoleClientset: clientset, recorder: recorder}
+
+	reaped, err := client.CleanupExpiredResources(testNamespace)
+	if err != nil {
+		t.Fatalf("CleanupExpiredResources() error = %v", err)
+	}
+
+	slices.Sort(reaped)
+	want := []string{"expired", "failed", "legacy", "stuck"}
+	if !slices.Equal(reaped, want) {
+		t.Errorf("reaped = %v, want %v", reaped, want)
+	}
+
+	ctx := context.Background()
+	for _, sessionID := range []string{"expired", "legacy", "failed", "stuck", "active", "provisioning"} {
+		kept := !slices.Contains(want, sessionID)
+		name := "console-" + sessionID
+		_, depErr := clientset.AppsV1().Deployments(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		_, svcErr := clientset.CoreV1().Services(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		_, secErr := clientset.CoreV1().Secrets(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		for kind, err := range map[string]error{"deployment": depErr, "service": svcErr, "secret": secErr} {
+			if kept && err != nil {
+				t.Errorf("%s of session %s was deleted: %v", kind, sessionID, err)
+			}
+			if !kept && err == nil {
+				t.Errorf("%s of session %s was not deleted", kind, sessionID)
+			}
+		}
+	}
+
+	close(recorder.Events)
+	reasons := map[string]int{}
+	for event := range recorder.Events {
+		reasons[strings.Fields(event)[1]]++
+	}
+	if reasons["ConsoleExpired"] != 2 || reasons["ConsoleFailed"] != 2 {
+		t.Errorf("recorded event reasons = %v, want 2 ConsoleExpired and 2 ConsoleFailed", reasons)
+	}
+}
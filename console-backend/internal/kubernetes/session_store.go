@@ -0,0 +1,275 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// 세션 저장소 종류
+const (
+	SessionStoreMemory     = "memory"
+	SessionStoreKubernetes = "kubernetes"
+)
+
+// ErrSessionNotFound 세션이 저장소에 없을 때 반환되는 에러
+var ErrSessionNotFound = errors.New("console session not found")
+
+// SessionStore 웹 콘솔 세션 저장소 인터페이스
+type SessionStore interface {
+	// Save 세션 저장
+	Save(resource *ConsoleResource) error
+	// Get 세션 ID로 조회 (없으면 ErrSessionNotFound 반환)
+	Get(resourceID string) (*ConsoleResource, error)
+	// Delete 세션 제거
+	Delete(resourceID string) error
+	// ListByUser 사용자의 세션 목록 조회
+	ListByUser(userID string) ([]*ConsoleResource, error)
+	// List 전체 세션 목록 조회
+	List() ([]*ConsoleResource, error)
+}
+
+// NewSessionStore 설정된 종류의 세션 저장소 생성
+func NewSessionStore(client *Client, storeType, namespace string) (SessionStore, error) {
+	switch storeType {
+	case SessionStoreMemory:
+		return NewMemorySessionStore(), nil
+	case SessionStoreKubernetes, "":
+		return NewKubernetesSessionStore(client, namespace), nil
+	default:
+		return nil, fmt.Errorf("unknown session store type: %s", storeType)
+	}
+}
+
+// MemorySessionStore 프로세스 메모리 기반 세션 저장소 (단일 레플리카/개발 환경용)
+type MemorySessionStore struct {
+	mu        sync.RWMutex
+	resources map[string]*ConsoleResource
+}
+
+// NewMemorySessionStore 새로운 메모리 세션 저장소 생성
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		resources: make(map[string]*ConsoleResource),
+	}
+}
+
+// Save 세션 저장
+func (s *MemorySessionStore) Save(resource *ConsoleResource) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resources[resource.ID] = resource
+	return nil
+}
+
+// Get 세션 조회
+func (s *MemorySessionStore) Get(resourceID string) (*ConsoleResource, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	resource, exists := s.resources[resourceID]
+	if !exists {
+		return nil, ErrSessionNotFound
+	}
+	return resource, nil
+}
+
+// Delete 세션 제거
+func (s *MemorySessionStore) Delete(resourceID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.resources, resourceID)
+	return nil
+}
+
+// ListByUser 사용자의 세션 목록 조회
+func (s *MemorySessionStore) ListByUser(userID string) ([]*ConsoleResource, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*ConsoleResource, 0)
+	for _, resource := range s.resources {
+		if resource.UserID == userID {
+			result = append(result, resource)
+		}
+	}
+	sortByCreatedAt(result)
+	return result, nil
+}
+
+// List 전체 세션 목록 조회
+func (s *MemorySessionStore) List() ([]*ConsoleResource, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*ConsoleResource, 0, len(s.resources))
+	for _, resource := range s.resources {
+		result = append(result, resource)
+	}
+	sortByCreatedAt(result)
+	return result, nil
+}
+
+// KubernetesSessionStore 클러스터의 app=web-console 라벨 오브젝트로부터 상태를 재구성하는 세션 저장소
+// 상태의 원본이 클러스터에 있으므로 재시작이나 다중 레플리카 환경에서도 세션이 유지됨
+type KubernetesSessionStore struct {
+	client    *Client
+	namespace string
+}
+
+// NewKubernetesSessionStore 새로운 쿠버네티스 세션 저장소 생성
+func NewKubernetesSessionStore(client *Client, namespace string) *KubernetesSessionStore {
+	return &KubernetesSessionStore{
+		client:    client,
+		namespace: namespace,
+	}
+}
+
+// Save 세션 저장 (CreateConsoleResources가 이미 라벨을 기록하므로 별도 작업 없음)
+func (s *KubernetesSessionStore) Save(resource *ConsoleResource) error {
+	return nil
+}
+
+// Get 세션 라벨로 리소스를 조회하여 세션 재구성
+func (s *KubernetesSessionStore) Get(resourceID string) (*ConsoleResource, error) {
+	sessions, err := s.client.listConsoleSessions(s.namespace, fmt.Sprintf("app=web-console,session=%s", resourceID))
+	if err != nil {
+		return nil, err
+	}
+
+	resource, exists := sessions[resourceID]
+	if !exists || resource.DeploymentName == "" {
+		return nil, ErrSessionNotFound
+	}
+	return resource, nil
+}
+
+// Delete 세션 제거 (리소스 삭제 시 라벨 오브젝트도 함께 사라지므로 별도 작업 없음)
+func (s *KubernetesSessionStore) Delete(resourceID string) error {
+	return nil
+}
+
+// ListByUser 사용자 라벨로 세션 목록 조회
+func (s *KubernetesSessionStore) ListByUser(userID string) ([]*ConsoleResource, error) {
+	return s.list(fmt.Sprintf("app=web-console,user=%s", userID))
+}
+
+// List 전체 세션 목록 조회
+func (s *KubernetesSessionStore) List() ([]*ConsoleResource, error) {
+	return s.list("app=web-console")
+}
+
+// list 라벨 셀렉터에 해당하는 세션 중 Deployment가 존재하는 세션만 반환
+func (s *KubernetesSessionStore) list(labelSelector string) ([]*ConsoleResource, error) {
+	sessions, err := s.client.listConsoleSessions(s.namespace, labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*ConsoleResource, 0, len(sessions))
+	for _, resource := range sessions {
+		if resource.DeploymentName != "" {
+			result = append(result, resource)
+		}
+	}
+	sortByCreatedAt(result)
+	return result, nil
+}
+
+// listConsoleSessions 공유 인포머 캐시에서 라벨이 붙은 Deployment, Service, Secret, Ingress를 조회하여 세션별로 묶어서 반환
+// 존재하지 않는 오브젝트의 이름 필드는 빈 문자열로 남음
+func (c *Client) listConsoleSessions(namespace, labelSelector string) (map[string]*ConsoleResource, error) {
+	if err := c.informers.checkNamespace(namespace); err != nil {
+		return nil, err
+	}
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %v", labelSelector, err)
+	}
+	sessions := make(map[string]*ConsoleResource)
+
+	// 세션 라벨 기준으로 ConsoleResource를 찾거나 생성
+	sessionFor := func(meta metav1.Object) *ConsoleResource {
+		sessionID, exists := meta.GetLabels()["session"]
+		if !exists {
+			return nil
+		}
+
+		resource, exists := sessions[sessionID]
+		if !exists {
+			userID := meta.GetLabels()["user"]
+			resource = &ConsoleResource{
+				ID:        sessionID,
+				UserID:    userID,
+				PVCName:   fmt.Sprintf("history-%s", userID),
+				Namespace: namespace,
+				CreatedAt: meta.GetCreationTimestamp().Time,
+			}
+			sessions[sessionID] = resource
+		}
+
+		// 가장 먼저 생성된 오브젝트의 시간을 세션 생성 시간으로 사용
+		if meta.GetCreationTimestamp().Time.Before(resource.CreatedAt) {
+			resource.CreatedAt = meta.GetCreationTimestamp().Time
+		}
+		return resource
+	}
+
+	ttl := time.Duration(GetDefaultConfig().TTLSeconds) * time.Second
+	for _, obj := range c.informers.list(c.informers.deployments, selector) {
+		deployment := obj.(*appsv1.Deployment)
+		if resource := sessionFor(deployment); resource != nil {
+			resource.DeploymentName = deployment.Name
+			resource.ExpiresAt = SessionExpiresAt(deployment.ObjectMeta, ttl)
+			resource.TargetNamespace = deployment.Annotations[AnnotationTargetNamespace]
+			resource.Cluster = deployment.Labels["cluster"]
+		}
+	}
+
+	for _, obj := range c.informers.list(c.informers.services, selector) {
+		if resource := sessionFor(obj); resource != nil {
+			resource.ServiceName = obj.GetName()
+		}
+	}
+
+	for _, obj := range c.informers.list(c.informers.secrets, selector) {
+		if resource := sessionFor(obj); resource != nil {
+			resource.SecretName = obj.GetName()
+			resource.TokenExpiresAt = parseAnnotationTime(obj.GetAnnotations(), AnnotationTokenExpiresAt)
+			resource.TokenRefreshError = obj.GetAnnotations()[AnnotationTokenRefreshError]
+		}
+	}
+
+	for _, obj := range c.informers.list(c.informers.ingresses, selector) {
+		ingress := obj.(*networkingv1.Ingress)
+		resource := sessionFor(ingress)
+		if resource == nil {
+			continue
+		}
+		resource.IngressName = ingress.Name
+
+		// 콘솔 URL은 Ingress의 호스트와 경로로 복원
+		if len(ingress.Spec.Rules) > 0 && ingress.Spec.Rules[0].HTTP != nil && len(ingress.Spec.Rules[0].HTTP.Paths) > 0 {
+			rule := ingress.Spec.Rules[0]
+			resource.ConsoleURL = fmt.Sprintf("https://%s%s", rule.Host, rule.HTTP.Paths[0].Path)
+		}
+	}
+
+	return sessions, nil
+}
+
+// sortByCreatedAt 생성 시간 순으로 정렬
+func sortByCreatedAt(resources []*ConsoleResource) {
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].CreatedAt.Before(resources[j].CreatedAt)
+	})
+}
@@ -0,0 +1,316 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ConsolePhase 콘솔 프로비저닝 단계
+type ConsolePhase string
+
+const (
+	PhaseCreating     ConsolePhase = "creating"      // 오브젝트 생성 직후, Pod 생성 전
+	PhaseScheduling   ConsolePhase = "scheduling"    // Pod가 노드에 배치되기를 기다리는 중
+	PhasePullingImage ConsolePhase = "pulling image" // 이미지 다운로드 및 컨테이너 생성 중
+	PhaseStarting     ConsolePhase = "starting"      // 컨테이너 실행 중, 트래픽 수신 준비 전
+	PhaseReady        ConsolePhase = "ready"         // 접속 가능
+	PhaseFailed       ConsolePhase = "failed"        // 프로비저닝 실패
+)
+
+// 프로비저닝 상태 어노테이션 키
+const (
+	AnnotationPhase         = "web-console/phase"          // 실패 시에만 기록되는 최종 단계
+	AnnotationFailureReason = "web-console/failure-reason" // 실패 사유
+)
+
+// ProvisioningTimeout 콘솔이 준비되기까지 허용되는 최대 시간
+const ProvisioningTimeout = 2 * time.Minute
+
+// 컨테이너 대기 사유 중 자동으로 회복되지 않는 사유
+var fatalWaitingReasons = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// ConsoleStatus 콘솔 프로비저닝 상태
+type ConsoleStatus struct {
+	ResourceID string       `json:"resource_id"`
+	UserID     string       `json:"user_id"`
+	Phase      ConsolePhase `json:"phase"`
+	Ready      bool         `json:"ready"`
+	Reason     string       `json:"reason,omitempty"`
+	Message    string       `json:"message,omitempty"`
+	ConsoleURL string       `json:"console_url"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ExpiresAt  time.Time    `json:"expires_at"`
+
+	TokenExpiresAt    *time.Time `json:"token_expires_at,omitempty"`    // 콘솔 쿠버네티스 토큰 만료 시간
+	TokenRefreshError string     `json:"token_refresh_error,omitempty"` // 마지막 토큰 갱신 실패 사유
+}
+
+// GetConsoleStatus 세션 오브젝트와 Pod 상태로부터 현재 프로비저닝 단계 계산
+func (c *Client) GetConsoleStatus(namespace, sessionID string) (*ConsoleStatus, error) {
+	sessions, err := c.listConsoleSessions(namespace, fmt.Sprintf("app=web-console,session=%s", sessionID))
+	if err != nil {
+		return nil, err
+	}
+	resource, exists := sessions[sessionID]
+	if !exists || resource.DeploymentName == "" {
+		return nil, ErrSessionNotFound
+	}
+
+	// 상태 조회는 공유 인포머 캐시를 사용하고, 막 생성되어 캐시에 없는 경우에만 API 서버 조회
+	deployment, cached := c.informers.getDeployment(namespace, resource.DeploymentName)
+	if !cached {
+		var err error
+		deployment, err = c.ConsoleClientset.AppsV1().Deployments(namespace).Get(context.Background(), resource.DeploymentName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment: %v", err)
+		}
+	}
+
+	pods := c.informers.listSessionPods(namespace, sessionID)
+
+	endpointsReady := false
+	if endpoints, exists := c.informers.getEndpoints(namespace, resource.ServiceName); exists {
+		endpointsReady = hasReadyEndpoints(endpoints)
+	}
+
+	status := &ConsoleStatus{
+		ResourceID: resource.ID,
+		UserID:     resource.UserID,
+		ConsoleURL: resource.ConsoleURL,
+		CreatedAt:  resource.CreatedAt,
+		ExpiresAt:  resource.ExpiresAt,
+
+		TokenRefreshError: resource.TokenRefreshError,
+	}
+	if !resource.TokenExpiresAt.IsZero() {
+		status.TokenExpiresAt = &resource.TokenExpiresAt
+	}
+	status.Phase, status.Reason, status.Message = consolePhase(deployment, pods, endpointsReady)
+	status.Ready = status.Phase == PhaseReady
+
+	return status, nil
+}
+
+// consolePhase Deployment, Pod, Endpoint 상태로 프로비저닝 단계 결정
+func consolePhase(deployment *appsv1.Deployment, pods []corev1.Pod, endpointsReady bool) (ConsolePhase, string, string) {
+	if deployment.Annotations[AnnotationPhase] == string(PhaseFailed) {
+		return PhaseFailed, "ProvisioningFailed", deployment.Annotations[AnnotationFailureReason]
+	}
+
+	if IsDeploymentReady(deployment) && endpointsReady {
+		return PhaseReady, "", ""
+	}
+
+	phase, reason, message := PhaseCreating, "", ""
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		phase, reason, message = podPhase(pod)
+		if phase == PhaseFailed {
+			return phase, reason, message
+		}
+	}
+
+	// 제한 시간이 지나도 준비되지 않으면 실패로 간주
+	if time.Since(deployment.CreationTimestamp.Time) > ProvisioningTimeout {
+		if reason == "" {
+			reason = "ProvisioningTimeout"
+		}
+		return PhaseFailed, reason, fmt.Sprintf("console not ready after %s (last phase: %s) %s", ProvisioningTimeout, phase, message)
+	}
+
+	return phase, reason, message
+}
+
+// podPhase 단일 Pod의 상태로 프로비저닝 단계 결정
+func podPhase(pod *corev1.Pod) (ConsolePhase, string, string) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status != corev1.ConditionTrue {
+			return PhaseScheduling, condition.Reason, condition.Message
+		}
+	}
+	if pod.Spec.NodeName == "" {
+		return PhaseScheduling, "", ""
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if waiting := containerStatus.State.Waiting; waiting != nil {
+			if fatalWaitingReasons[waiting.Reason] {
+				return PhaseFailed, waiting.Reason, waiting.Message
+			}
+			return PhasePullingImage, waiting.Reason, waiting.Message
+		}
+		if terminated := containerStatus.State.Terminated; terminated != nil {
+			return PhaseFailed, terminated.Reason, terminated.Message
+		}
+	}
+
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return PhasePullingImage, "", ""
+	}
+	return PhaseStarting, "", ""
+}
+
+// hasReadyEndpoints Endpoint에 준비된 주소가 있는지 확인
+func hasReadyEndpoints(endpoints *corev1.Endpoints) bool {
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForConsoleReady 백그라운드에서 콘솔 준비 상태를 기다리고, Pod가 회복할 수 없는 상태가 되면 실패 사유를 기록
+// 모든 대기는 생성 시점부터 ProvisioningTimeout 안에서 진행 (상태 조회, 정리 루틴과 같은 기준)
+// 시간 초과는 상태 조회가 failed로 보고하고 정리 루틴이 삭제하므로 로그만 남김
+func (c *Client) waitForConsoleReady(resource *ConsoleResource) {
+	deadline := resource.CreatedAt.Add(ProvisioningTimeout)
+
+	// Deployment가 준비되거나 Pod가 실패할 때까지 대기
+	log.Printf("Waiting for Deployment %s to be ready...", resource.DeploymentName)
+	if err := c.waitForDeploymentOrPodFailure(resource, time.Until(deadline)); err != nil {
+		var failure *podFailure
+		if errors.As(err, &failure) {
+			log.Printf("Console %s failed during provisioning: %v", resource.ID, failure)
+			c.markConsoleFailed(resource, failure.Error())
+			return
+		}
+		log.Printf("Deployment %s not ready within provisioning timeout: %v", resource.DeploymentName, err)
+		return
+	}
+	log.Printf("Deployment %s is ready", resource.DeploymentName)
+
+	// Service Endpoint가 준비될 때까지 대기 (남은 시간 안에서)
+	log.Printf("Waiting for Service %s endpoints to be ready...", resource.ServiceName)
+	if err := c.WaitForServiceReady(resource.ServiceName, resource.Namespace, time.Until(deadline)); err != nil {
+		log.Printf("Service %s endpoints not ready within provisioning timeout: %v", resource.ServiceName, err)
+		return
+	}
+	log.Printf("Service %s is ready with endpoints", resource.ServiceName)
+
+	// Ingress가 준비될 때까지 대기 (남은 시간 안에서)
+	log.Printf("Waiting for Ingress %s to be ready...", resource.IngressName)
+	if err := c.WaitForIngressReady(resource.IngressName, resource.Namespace, time.Until(deadline)); err != nil {
+		// Ingress는 경고만 출력 (백그라운드에서 준비될 수 있음)
+		log.Printf("Warning: Ingress %s not fully ready after timeout: %v. It may need more time.", resource.IngressName, err)
+		return
+	}
+	log.Printf("Ingress %s is ready", resource.IngressName)
+}
+
+// podFailure 세션 Pod가 회복할 수 없는 상태가 되었음을 나타내는 에러
+type podFailure struct {
+	podName string
+	reason  string
+	message string
+}
+
+func (e *podFailure) Error() string {
+	return fmt.Sprintf("pod %s failed: %s %s", e.podName, e.reason, e.message)
+}
+
+// waitForDeploymentOrPodFailure Deployment가 준비되거나 세션 Pod가 실패(podPhase 기준)할 때까지 대기
+// Pod 실패 시 *podFailure, 시간 초과 시 일반 에러 반환
+func (c *Client) waitForDeploymentOrPodFailure(resource *ConsoleResource, timeout time.Duration) error {
+	if err := c.informers.checkNamespace(resource.Namespace); err != nil {
+		return err
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("provisioning timeout of %s already elapsed", ProvisioningTimeout)
+	}
+
+	// 두 인포머의 핸들러가 서로 다른 고루틴에서 호출되므로 첫 결과만 버퍼에 남김
+	result := make(chan error, 1)
+	signal := func(err error) {
+		select {
+		case result <- err:
+		default:
+		}
+	}
+
+	checkDeployment := func(obj any) {
+		deployment, ok := obj.(*appsv1.Deployment)
+		if ok && deployment.Namespace == resource.Namespace && deployment.Name == resource.DeploymentName && IsDeploymentReady(deployment) {
+			signal(nil)
+		}
+	}
+	checkPod := func(obj any) {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok || pod.Namespace != resource.Namespace || pod.Labels["session"] != resource.ID || pod.DeletionTimestamp != nil {
+			return
+		}
+		if phase, reason, message := podPhase(pod); phase == PhaseFailed {
+			signal(&podFailure{podName: pod.Name, reason: reason, message: message})
+		}
+	}
+
+	for _, h := range []struct {
+		informer cache.SharedIndexInformer
+		check    func(obj any)
+	}{
+		{c.informers.deployments, checkDeployment},
+		{c.informers.pods, checkPod},
+	} {
+		informer := h.informer
+		registration, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    h.check,
+			UpdateFunc: func(_, newObj any) { h.check(newObj) },
+		})
+		if err != nil {
+			return fmt.Errorf("failed to register informer handler: %v", err)
+		}
+		defer func() {
+			if err := informer.RemoveEventHandler(registration); err != nil {
+				log.Printf("Failed to remove informer handler for session %s: %v", resource.ID, err)
+			}
+		}()
+	}
+
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %s waiting for %s/%s", timeout, resource.Namespace, resource.DeploymentName)
+	}
+}
+
+// markConsoleFailed Deployment에 실패 단계와 사유 기록 (정리 루틴이 이후 리소스 삭제)
+func (c *Client) markConsoleFailed(resource *ConsoleResource, reason string) {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				AnnotationPhase:         string(PhaseFailed),
+				AnnotationFailureReason: reason,
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to build failure patch for %s: %v", resource.DeploymentName, err)
+		return
+	}
+
+	_, err = c.ConsoleClientset.AppsV1().Deployments(resource.Namespace).Patch(context.Background(), resource.DeploymentName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		log.Printf("Failed to mark Deployment %s as failed: %v", resource.DeploymentName, err)
+	}
+}
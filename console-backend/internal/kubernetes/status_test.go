@@ -0,0 +1,183 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodPhase(t *testing.T) {
+	scheduled := corev1.PodSpec{NodeName: "node-1"}
+	waiting := func(reason string) corev1.PodStatus {
+		return corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}},
+		}}}
+	}
+
+	tests := []struct {
+		name       string
+		pod        corev1.Pod
+		wantPhase  ConsolePhase
+		wantReason string
+	}{
+		{
+			name: "unschedulable",
+			pod: corev1.Pod{Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+				Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: "Unschedulable",
+			}}}},
+			wantPhase:  PhaseScheduling,
+			wantReason: "Unschedulable",
+		},
+		{
+			name:      "not yet bound to a node",
+			pod:       corev1.Pod{},
+			wantPhase: PhaseScheduling,
+		},
+		{
+			name:      "no container status yet",
+			pod:       corev1.Pod{Spec: scheduled},
+			wantPhase: PhasePullingImage,
+		},
+		{
+			name:       "pulling image",
+			pod:        corev1.Pod{Spec: scheduled, Status: waiting("ContainerCreating")},
+			wantPhase:  PhasePullingImage,
+			wantReason: "ContainerCreating",
+		},
+		{
+			name:       "image pull back-off is fatal",
+			pod:        corev1.Pod{Spec: scheduled, Status: waiting("ImagePullBackOff")},
+			wantPhase:  PhaseFailed,
+			wantReason: "ImagePullBackOff",
+		},
+		{
+			name:       "crash loop is fatal",
+			pod:        corev1.Pod{Spec: scheduled, Status: waiting("CrashLoopBackOff")},
+			wantPhase:  PhaseFailed,
+			wantReason: "CrashLoopBackOff",
+		},
+		{
+			name: "terminated container",
+			pod: corev1.Pod{Spec: scheduled, Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error"}},
+			}}}},
+			wantPhase:  PhaseFailed,
+			wantReason: "Error",
+		},
+		{
+			name: "running",
+			pod: corev1.Pod{Spec: scheduled, Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}}}},
+			wantPhase: PhaseStarting,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phase, reason, _ := podPhase(&tt.pod)
+			if phase != tt.wantPhase || reason != tt.wantReason {
+				t.Errorf("podPhase() = (%q, %q), want (%q, %q)", phase, reason, tt.wantPhase, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestConsolePhase(t *testing.T) {
+	now := time.Now()
+	deployment := func(created time.Time, status appsv1.DeploymentStatus, annotations map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created), Annotations: annotations},
+			Status:     status,
+		}
+	}
+	ready := appsv1.DeploymentStatus{Replicas: 1, ReadyReplicas: 1}
+	notReady := appsv1.DeploymentStatus{Replicas: 1}
+	running := corev1.Pod{Spec: corev1.PodSpec{NodeName: "node-1"}, Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}}}}
+	backOff := corev1.Pod{Spec: corev1.PodSpec{NodeName: "node-1"}, Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+	}}}}
+	terminating := backOff
+	terminating.DeletionTimestamp = &metav1.Time{Time: now}
+
+	tests := []struct {
+		name           string
+		deployment     *appsv1.Deployment
+		pods           []corev1.Pod
+		endpointsReady bool
+		wantPhase      ConsolePhase
+		wantReason     string
+	}{
+		{
+			name:       "no pods yet",
+			deployment: deployment(now, notReady, nil),
+			wantPhase:  PhaseCreating,
+		},
+		{
+			name:           "ready with endpoints",
+			deployment:     deployment(now.Add(-time.Minute), ready, nil),
+			pods:           []corev1.Pod{running},
+			endpointsReady: true,
+			wantPhase:      PhaseReady,
+		},
+		{
+			name:       "ready deployment waits for endpoints",
+			deployment: deployment(now.Add(-time.Minute), ready, nil),
+			pods:       []corev1.Pod{running},
+			wantPhase:  PhaseStarting,
+		},
+		{
+			name:           "failed annotation wins over ready status",
+			deployment:     deployment(now, ready, map[string]string{AnnotationPhase: string(PhaseFailed), AnnotationFailureReason: "ImagePullBackOff"}),
+			pods:           []corev1.Pod{running},
+			endpointsReady: true,
+			wantPhase:      PhaseFailed,
+			wantReason:     "ProvisioningFailed",
+		},
+		{
+			name:       "fatal pod state",
+			deployment: deployment(now, notReady, nil),
+			pods:       []corev1.Pod{backOff},
+			wantPhase:  PhaseFailed,
+			wantReason: "ImagePullBackOff",
+		},
+		{
+			name:       "terminating pods are ignored",
+			deployment: deployment(now, notReady, nil),
+			pods:       []corev1.Pod{terminating, running},
+			wantPhase:  PhaseStarting,
+		},
+		{
+			name:       "not ready after provisioning timeout",
+			deployment: deployment(now.Add(-ProvisioningTimeout-time.Second), notReady, nil),
+			pods:       []corev1.Pod{running},
+			wantPhase:  PhaseFailed,
+			wantReason: "ProvisioningTimeout",
+		},
+		{
+			name:           "ready after provisioning timeout",
+			deployment:     deployment(now.Add(-time.Hour), ready, nil),
+			pods:           []corev1.Pod{running},
+			endpointsReady: true,
+			wantPhase:      PhaseReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phase, reason, message := consolePhase(tt.deployment, tt.pods, tt.endpointsReady)
+			if phase != tt.wantPhase || reason != tt.wantReason {
+				t.Errorf("consolePhase() = (%q, %q), want (%q, %q)", phase, reason, tt.wantPhase, tt.wantReason)
+			}
+			if tt.wantReason == "ProvisioningTimeout" && !strings.Contains(message, string(PhaseStarting)) {
+				t.Errorf("timeout message %q does not include the last phase", message)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"portal-backend/internal/auth"
+)
+
+// refreshTokenKey 세션 Secret에 저장되는 refresh token 키 (콘솔 Pod에는 마운트하지 않음)
+const refreshTokenKey = "refresh-token"
+
+// 토큰 갱신 관련 Secret 어노테이션 키
+const (
+	AnnotationTokenExpiresAt    = "web-console/token-expires-at"    // 쿠버네티스 토큰 만료 시간 (RFC3339)
+	AnnotationRefreshExpiresAt  = "web-console/refresh-expires-at"  // refresh token 만료 시간 (RFC3339)
+	AnnotationTokenRefreshedAt  = "web-console/token-refreshed-at"  // 마지막 갱신 성공 시간 (RFC3339)
+	AnnotationTokenRefreshError = "web-console/token-refresh-error" // 마지막 갱신 실패 사유 (성공 시 제거)
+	AnnotationTokenRefreshClaim = "web-console/token-refresh-claim" // 갱신 중인 레플리카와 선점 만료 시간 ("<holder> <RFC3339>")
+)
+
+// tokenRefreshClaimTTL 갱신 선점 유효 시간
+// 선점한 레플리카가 IdP 호출 도중 중단되더라도 이 시간이 지나면 다른 레플리카가 다시 선점할 수 있음
+const tokenRefreshClaimTTL = 30 * time.Second
+
+// refreshClaimHolder 선점 어노테이션에 기록할 현재 레플리카 식별자 (Pod 이름)
+var refreshClaimHolder = func() string {
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return fmt.Sprintf("pid-%d", os.Getpid())
+}()
+
+// tokenAnnotations 토큰 교환/갱신 응답으로부터 만료 시간 어노테이션 생성
+func tokenAnnotations(token *auth.TokenExchangeResponse, issuedAt time.Time) map[string]string {
+	annotations := make(map[string]string)
+	if token.ExpiresIn > 0 {
+		annotations[AnnotationTokenExpiresAt] = issuedAt.Add(time.Duration(token.ExpiresIn) * time.Second).UTC().Format(time.RFC3339)
+	}
+	if token.RefreshExpiresIn > 0 {
+		annotations[AnnotationRefreshExpiresAt] = issuedAt.Add(time.Duration(token.RefreshExpiresIn) * time.Second).UTC().Format(time.RFC3339)
+	}
+	return annotations
+}
+
+// parseAnnotationTime RFC3339 어노테이션 값 파싱 (없거나 잘못된 값이면 zero time)
+func parseAnnotationTime(annotations map[string]string, key string) time.Time {
+	value, exists := annotations[key]
+	if !exists {
+		return time.Time{}
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
+
+// RefreshConsoleTokens 만료가 refreshBefore 이내로 다가온 세션 토큰을 갱신하여 세션 Secret을 다시 기록
+// Secret은 디렉토리로 마운트되어 있으므로 콘솔 Pod의 토큰 파일도 kubelet 동기화 주기 안에 갱신됨
+// 갱신에 성공한 세션 수를 반환하고, 실패는 Secret의 token-refresh-error 어노테이션으로 기록
+func (c *Client) RefreshConsoleTokens(namespace string, refreshBefore time.Duration) (int, error) {
+	ctx := context.Background()
+
+	secrets, err := c.ConsoleClientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app=web-console,session",
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list session secrets: %v", err)
+	}
+
+	now := time.Now()
+	refreshed := 0
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if secret.DeletionTimestamp != nil {
+			continue
+		}
+
+		tokenExpiresAt := parseAnnotationTime(secret.Annotations, AnnotationTokenExpiresAt)
+		if tokenExpiresAt.IsZero() || tokenExpiresAt.Sub(now) > refreshBefore {
+			continue
+		}
+
+		// 모든 레플리카가 갱신 루틴을 실행하므로 IdP 호출 전에 Secret을 선점하여 같은 refresh token을 두 번 사용하지 않도록 함
+		claimed, err := c.claimTokenRefresh(ctx, secret, now)
+		if err != nil {
+			log.Printf("Failed to claim token refresh for console session %s: %v", secret.Labels["session"], err)
+			continue
+		}
+		if claimed == nil {
+			continue
+		}
+
+		if err := c.refreshSessionToken(ctx, claimed, now); err != nil {
+			log.Printf("Failed to refresh token for console session %s: %v", secret.Labels["session"], err)
+			c.recordTokenRefreshError(ctx, claimed, err)
+			continue
+		}
+		refreshed++
+	}
+
+	return refreshed, nil
+}
+
+// claimTokenRefresh resourceVersion 조건부 Update로 선점 어노테이션을 기록하고 선점된 Secret 반환
+// 다른 레플리카가 유효한 선점을 보유하고 있거나 먼저 Secret을 변경했으면 nil 반환
+func (c *Client) claimTokenRefresh(ctx context.Context, secret *corev1.Secret, now time.Time) (*corev1.Secret, error) {
+	if holder, expiresAt := parseRefreshClaim(secret.Annotations[AnnotationTokenRefreshClaim]); holder != "" && now.Before(expiresAt) {
+		return nil, nil
+	}
+
+	claim := secret.DeepCopy()
+	if claim.Annotations == nil {
+		claim.Annotations = make(map[string]string)
+	}
+	claim.Annotations[AnnotationTokenRefreshClaim] = fmt.Sprintf("%s %s", refreshClaimHolder, now.Add(tokenRefreshClaimTTL).UTC().Format(time.RFC3339))
+
+	claimed, err := c.ConsoleClientset.CoreV1().Secrets(secret.Namespace).Update(ctx, claim, metav1.UpdateOptions{})
+	if apierrors.IsConflict(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim session secret: %v", err)
+	}
+	return claimed, nil
+}
+
+// parseRefreshClaim 선점 어노테이션 값을 보유자와 만료 시간으로 분리 (형식이 잘못되면 빈 보유자)
+func parseRefreshClaim(value string) (string, time.Time) {
+	holder, expiry, found := strings.Cut(value, " ")
+	if !found {
+		return "", time.Time{}
+	}
+	expiresAt, err := time.Parse(time.RFC3339, expiry)
+	if err != nil {
+		return "", time.Time{}
+	}
+	return holder, expiresAt
+}
+
+// refreshSessionToken 세션 Secret의 refresh token으로 토큰을 갱신하고 Secret 갱신
+func (c *Client) refreshSessionToken(ctx context.Context, secret *corev1.Secret, now time.Time) error {
+	refreshToken := string(secret.Data[refreshTokenKey])
+	if refreshToken == "" {
+		return fmt.Errorf("no refresh token stored for session")
+	}
+
+	refreshExpiresAt := parseAnnotationTime(secret.Annotations, AnnotationRefreshExpiresAt)
+	if !refreshExpiresAt.IsZero() && now.After(refreshExpiresAt) {
+		return fmt.Errorf("refresh token expired at %s", refreshExpiresAt.Format(time.RFC3339))
+	}
+
+	token, err := auth.RefreshKubernetesToken(refreshToken)
+	if err != nil {
+		return err
+	}
+
+	updated := secret.DeepCopy()
+	updated.Data[kubeTokenKey] = []byte(token.AccessToken)
+	if token.RefreshToken != "" {
+		// refresh token 회전을 사용하는 IdP는 매번 새 refresh token을 발급
+		updated.Data[refreshTokenKey] = []byte(token.RefreshToken)
+	}
+	for key, value := range tokenAnnotations(token, now) {
+		updated.Annotations[key] = value
+	}
+	updated.Annotations[AnnotationTokenRefreshedAt] = now.UTC().Format(time.RFC3339)
+	delete(updated.Annotations, AnnotationTokenRefreshError)
+	delete(updated.Annotations, AnnotationTokenRefreshClaim)
+
+	// 선점한 Secret의 resourceVersion으로 Update하므로 선점 이후 다른 변경이 있었다면 충돌로 실패
+	if _, err := c.ConsoleClientset.CoreV1().Secrets(secret.Namespace).Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update session secret: %v", err)
+	}
+
+	log.Printf("Refreshed Kubernetes token for console session %s (expires at %s)",
+		secret.Labels["session"], updated.Annotations[AnnotationTokenExpiresAt])
+	return nil
+}
+
+// recordTokenRefreshError 갱신 실패 사유를 Secret 어노테이션으로 기록하고 선점 해제 (콘솔 상태 조회 시 노출)
+func (c *Client) recordTokenRefreshError(ctx context.Context, secret *corev1.Secret, refreshErr error) {
+	message := fmt.Sprintf("%s: %v", time.Now().UTC().Format(time.RFC3339), refreshErr)
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{
+				AnnotationTokenRefreshError: message,
+				AnnotationTokenRefreshClaim: nil,
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to build refresh error patch for %s: %v", secret.Name, err)
+		return
+	}
+
+	_, err = c.ConsoleClientset.CoreV1().Secrets(secret.Namespace).Patch(ctx, secret.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		log.Printf("Failed to record refresh error on Secret %s: %v", secret.Name, err)
+	}
+}
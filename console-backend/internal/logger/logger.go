@@ -131,10 +131,10 @@ func (l *Logger) log(level LogLevel, message string, ctx context.Context, extra
 
 	// 컨텍스트에서 정보 추출
 	if ctx != nil {
-		if requestID, ok := ctx.Value("request_id").(string); ok {
+		if requestID, ok := ctx.Value(RequestIDKey).(string); ok {
 			entry.RequestID = requestID
 		}
-		if userID, ok := ctx.Value("user_id").(string); ok {
+		if userID, ok := ctx.Value(UserIDKey).(string); ok {
 			entry.UserID = userID
 		}
 	}
@@ -218,10 +218,10 @@ func (l *Logger) ErrorWithContext(ctx context.Context, message string, err error
 	entry.Line = line
 
 	if ctx != nil {
-		if requestID, ok := ctx.Value("request_id").(string); ok {
+		if requestID, ok := ctx.Value(RequestIDKey).(string); ok {
 			entry.RequestID = requestID
 		}
-		if userID, ok := ctx.Value("user_id").(string); ok {
+		if userID, ok := ctx.Value(UserIDKey).(string); ok {
 			entry.UserID = userID
 		}
 	}
@@ -305,10 +305,10 @@ func LogHTTPRequest(ctx context.Context, method, path string, statusCode int, du
 	}
 
 	if ctx != nil {
-		if requestID, ok := ctx.Value("request_id").(string); ok {
+		if requestID, ok := ctx.Value(RequestIDKey).(string); ok {
 			entry.RequestID = requestID
 		}
-		if userID, ok := ctx.Value("user_id").(string); ok {
+		if userID, ok := ctx.Value(UserIDKey).(string); ok {
 			entry.UserID = userID
 		}
 	}
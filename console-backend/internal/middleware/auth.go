@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"portal-backend/internal/auth"
+	"portal-backend/internal/logger"
+	"portal-backend/internal/models"
+	"portal-backend/internal/utils"
+)
+
+// IdentityKey 인증된 사용자 정보를 gin 컨텍스트에 저장할 때 사용하는 키
+const IdentityKey = "identity"
+
+// AuthMiddleware 액세스 토큰을 한 번 검증하고 사용자 정보(Identity)를 컨텍스트에 저장하는 미들웨어
+// Authorization Bearer 헤더가 없으면 portal-jwt 쿠키가 가리키는 서버 측 로그인 세션의 액세스 토큰 사용
+// 요청 로그에 사용자 ID가 남도록 user_id 키와 logger.UserIDKey도 함께 설정
+func AuthMiddleware(provider *auth.OIDCProvider, sessions *auth.SessionManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accessToken, fromCookie, err := requestAccessToken(c, sessions)
+		if err != nil {
+			logger.WarnWithContext(c.Request.Context(), "Failed to resolve access token", map[string]any{
+				"error": err.Error(),
+			})
+			// 로그인 세션이 없거나 만료되었으면 AUTH004로 응답하여 다시 로그인하도록 함
+			// 세션은 유효하지만 IdP 장애로 토큰을 갱신하지 못했으면 503으로 응답하여 재시도하도록 함
+			switch {
+			case errors.Is(err, auth.ErrSessionNotFound):
+				utils.Response.Error(c, models.ErrSessionNotFound.WithDetails(err.Error()))
+			case fromCookie:
+				utils.Response.Error(c, models.ErrServiceUnavailable.WithDetails("Token refresh failed, try again later").WithCause(err))
+			default:
+				utils.Response.Unauthorized(c, err.Error())
+			}
+			c.Abort()
+			return
+		}
+
+		identity, err := provider.Authenticate(c.Request.Context(), accessToken)
+		if err != nil {
+			logger.WarnWithContext(c.Request.Context(), "Failed to verify OIDC token", map[string]any{
+				"error": err.Error(),
+			})
+			utils.Response.Unauthorized(c, fmt.Sprintf("Invalid OIDC token: %s", err.Error()))
+			c.Abort()
+			return
+		}
+
+		c.Set(IdentityKey, identity)
+		c.Set(cookieAuthKey, fromCookie)
+		c.Set("user_id", identity.UserID())
+
+		ctx := context.WithValue(c.Request.Context(), logger.UserIDKey, identity.UserID())
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// requestAccessToken Bearer 헤더(OIDC Access Token) 또는 portal-jwt 쿠키의 로그인 세션에서 액세스 토큰 추출
+// fromCookie는 쿠키로 인증했는지 여부 (RequireBearer에서 사용)
+func requestAccessToken(c *gin.Context, sessions *auth.SessionManager) (accessToken string, fromCookie bool, err error) {
+	if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			return "", false, fmt.Errorf("authorization header must use the Bearer scheme")
+		}
+		return strings.TrimPrefix(authHeader, "Bearer "), false, nil
+	}
+
+	cookie, err := c.Cookie(auth.SessionCookieName)
+	if err != nil {
+		return "", false, fmt.Errorf("authorization header with Bearer token or %s cookie is required", auth.SessionCookieName)
+	}
+
+	claims, err := sessions.ParseCookieToken(cookie)
+	if err != nil {
+		return "", true, err
+	}
+
+	// 만료가 임박한 토큰은 리프레시 토큰으로 갱신된 뒤 반환됨
+	accessToken, err = sessions.AccessToken(claims.SessionID)
+	if err != nil {
+		return "", true, err
+	}
+	return accessToken, true, nil
+}
+
+// GetIdentity AuthMiddleware가 저장한 사용자 정보 조회
+func GetIdentity(c *gin.Context) (*auth.Identity, bool) {
+	value, exists := c.Get(IdentityKey)
+	if !exists {
+		return nil, false
+	}
+	identity, ok := value.(*auth.Identity)
+	return identity, ok
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"portal-backend/internal/auth"
+	"portal-backend/internal/logger"
+	"portal-backend/internal/models"
+	"portal-backend/internal/utils"
+)
+
+// CSRFHeader 쿠키로 인증하는 상태 변경 요청에 필요한 헤더
+// 다른 사이트의 폼이나 이미지 요청은 사용자 정의 헤더를 보낼 수 없고, 스크립트 요청은 CORS preflight에서 걸러짐
+const CSRFHeader = "X-Requested-With"
+
+// cookieAuthKey AuthMiddleware가 portal-jwt 쿠키로 인증했음을 표시하는 키
+const cookieAuthKey = "cookie_auth"
+
+// CSRFMiddleware Bearer 헤더 없이 portal-jwt 쿠키만으로 보내는 상태 변경 요청(GET, HEAD, OPTIONS 외)에 CSRFHeader 요구
+// 쿠키를 쓰지 않는 요청(Bearer 토큰, 콘솔 Pod의 heartbeat)은 검사하지 않음
+func CSRFMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		if c.GetHeader("Authorization") != "" || c.GetHeader(CSRFHeader) != "" {
+			c.Next()
+			return
+		}
+		if _, err := c.Cookie(auth.SessionCookieName); err != nil {
+			c.Next()
+			return
+		}
+
+		logger.WarnWithContext(c.Request.Context(), "Rejected cookie-authenticated request without CSRF header", map[string]any{
+			"method": c.Request.Method,
+			"path":   c.Request.URL.Path,
+		})
+		utils.Response.Error(c, models.ErrCSRFCheckFailed.WithDetails(CSRFHeader+" header is required"))
+		c.Abort()
+	}
+}
+
+// RequireBearer 상태를 바꾸는 GET 라우트(하위 호환 콘솔 실행)에서 쿠키 인증 거부
+// 쿠키로 인증하는 클라이언트는 같은 경로의 POST를 사용해야 CSRFMiddleware의 보호를 받음
+func RequireBearer() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool(cookieAuthKey) {
+			utils.Response.Error(c, models.ErrCSRFCheckFailed.WithDetails("Use POST for cookie-authenticated requests to this route"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"portal-backend/internal/auth"
+	"portal-backend/internal/models"
+)
+
+// serveCSRFRequest CSRFMiddleware를 거친 요청의 상태 코드와 에러 코드 반환
+func serveCSRFRequest(t *testing.T, method string, headers map[string]string, withCookie bool) (int, string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(CSRFMiddleware())
+	router.Handle(method, "/api/console/launch", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	request := httptest.NewRequest(method, "/api/console/launch", nil)
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	if withCookie {
+		request.AddCookie(&http.Cookie{Name: auth.SessionCookieName, Value: "signed-session"})
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder.Code, errorCode(t, recorder)
+}
+
+// errorCode 에러 응답 본문의 에러 코드 (에러 응답이 아니면 빈 문자열)
+func errorCode(t *testing.T, recorder *httptest.ResponseRecorder) string {
+	t.Helper()
+	if recorder.Body.Len() == 0 {
+		return ""
+	}
+	var body models.ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return body.Error.Code
+}
+
+func TestCSRFMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		headers    map[string]string
+		withCookie bool
+		wantStatus int
+	}{
+		{name: "cookie POST without header", method: http.MethodPost, withCookie: true, wantStatus: http.StatusForbidden},
+		{name: "cookie DELETE without header", method: http.MethodDelete, withCookie: true, wantStatus: http.StatusForbidden},
+		{name: "cookie POST with header", method: http.MethodPost, headers: map[string]string{CSRFHeader: "XMLHttpRequest"}, withCookie: true, wantStatus: http.StatusNoContent},
+		{name: "cookie GET is not checked", method: http.MethodGet, withCookie: true, wantStatus: http.StatusNoContent},
+		{name: "bearer POST is not checked", method: http.MethodPost, headers: map[string]string{"Authorization": "Bearer token"}, withCookie: true, wantStatus: http.StatusNoContent},
+		{name: "POST without cookie is not checked", method: http.MethodPost, wantStatus: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, code := serveCSRFRequest(t, tt.method, tt.headers, tt.withCookie)
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", status, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusForbidden && code != models.ErrCSRFCheckFailed.Code {
+				t.Errorf("error code = %q, want %q", code, models.ErrCSRFCheckFailed.Code)
+			}
+		})
+	}
+}
+
+func TestRequireBearer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, fromCookie := range []bool{false, true} {
+		router := gin.New()
+		router.GET("/api/launch-console", func(c *gin.Context) {
+			c.Set(cookieAuthKey, fromCookie)
+		}, RequireBearer(), func(c *gin.Context) {
+			c.Status(http.StatusNoContent)
+		})
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/launch-console", nil))
+
+		wantStatus := http.StatusNoContent
+		if fromCookie {
+			wantStatus = http.StatusForbidden
+		}
+		if recorder.Code != wantStatus {
+			t.Errorf("cookie auth %v: status = %d, want %d", fromCookie, recorder.Code, wantStatus)
+		}
+		if fromCookie && errorCode(t, recorder) != models.ErrCSRFCheckFailed.Code {
+			t.Errorf("error code = %q, want %q", errorCode(t, recorder), models.ErrCSRFCheckFailed.Code)
+		}
+	}
+}
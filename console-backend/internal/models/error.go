@@ -104,6 +104,13 @@ var (
 		HTTPStatus: http.StatusForbidden,
 	}
 
+	ErrCSRFCheckFailed = &APIError{
+		Type:       ErrorTypeAuthorization,
+		Code:       "AUTHZ003",
+		Message:    "Cookie-authenticated request failed CSRF check",
+		HTTPStatus: http.StatusForbidden,
+	}
+
 	// 리소스 관련 에러
 	ErrResourceNotFound = &APIError{
 		Type:       ErrorTypeNotFound,
@@ -119,6 +126,34 @@ var (
 		HTTPStatus: http.StatusNotFound,
 	}
 
+	ErrConsoleMaxLifetimeReached = &APIError{
+		Type:       ErrorTypeConflict,
+		Code:       "RES003",
+		Message:    "Web console has reached its maximum lifetime",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrConsoleUserLimitReached = &APIError{
+		Type:       ErrorTypeConflict,
+		Code:       "RES004",
+		Message:    "Maximum number of web consoles per user reached",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrConsoleCapacityExceeded = &APIError{
+		Type:       ErrorTypeRateLimit,
+		Code:       "RES005",
+		Message:    "Web console capacity exceeded, please try again later",
+		HTTPStatus: http.StatusTooManyRequests,
+	}
+
+	ErrClusterNotFound = &APIError{
+		Type:       ErrorTypeNotFound,
+		Code:       "RES006",
+		Message:    "Requested cluster not found",
+		HTTPStatus: http.StatusNotFound,
+	}
+
 	// 쿠버네티스 관련 에러
 	ErrKubernetesOperation = &APIError{
 		Type:       ErrorTypeInternal,
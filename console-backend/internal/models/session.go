@@ -14,14 +14,83 @@ type Session struct {
 	CreatedAt    time.Time `json:"created_at"` // 세션 생성 시간
 }
 
+// RefreshSessionResponse 로그인 세션 토큰 갱신 응답 (토큰은 반환하지 않음)
+type RefreshSessionResponse struct {
+	UserID    string    `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"` // 갱신된 액세스 토큰 만료 시간
+}
+
 // LaunchConsoleResponse 웹 콘솔 실행 응답
 type LaunchConsoleResponse struct {
 	URL        string `json:"url"`
 	ResourceID string `json:"resource_id"`
+	Reused     bool   `json:"reused"` // 기존 콘솔을 재사용했는지 여부
+	Status     string `json:"status"` // 프로비저닝 상태 (provisioning, ready)
+}
+
+// 웹 콘솔 실행 응답 상태
+const (
+	ConsoleStatusProvisioning = "provisioning"
+	ConsoleStatusReady        = "ready"
+)
+
+// ExtendConsoleRequest 웹 콘솔 만료 연장 요청
+type ExtendConsoleRequest struct {
+	ExtendSeconds int `json:"extend_seconds"` // 현재 시각부터 연장할 시간 (비어 있으면 기본 TTL)
 }
 
-// UserInfo 사용자 정보
-type UserInfo struct {
-	UserID   string `json:"user_id"`
-	LoggedIn bool   `json:"logged_in"`
+// ExtendConsoleResponse 웹 콘솔 만료 연장 응답
+type ExtendConsoleResponse struct {
+	ResourceID   string    `json:"resource_id"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	MaxExpiresAt time.Time `json:"max_expires_at"`
+}
+
+// ClusterInfo 사용 가능한 클러스터 정보
+type ClusterInfo struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Server      string `json:"server"`
+	Default     bool   `json:"default"`
+}
+
+// ListClustersResponse 클러스터 목록 응답
+type ListClustersResponse struct {
+	Clusters []ClusterInfo `json:"clusters"`
+	Count    int           `json:"count"`
+}
+
+// NamespaceInfo 사용 가능한 네임스페이스 정보
+type NamespaceInfo struct {
+	Name    string `json:"name"`
+	Role    string `json:"role"`
+	Default bool   `json:"default"`
+}
+
+// ListNamespacesResponse 네임스페이스 목록 응답
+type ListNamespacesResponse struct {
+	Namespaces       []NamespaceInfo `json:"namespaces"`
+	Count            int             `json:"count"`
+	DefaultNamespace string          `json:"default_namespace"`
+	ClusterAdmin     bool            `json:"cluster_admin"` // 목록에 없는 네임스페이스도 선택 가능
+}
+
+// UserProfile 인증된 사용자 프로필 (GET /api/me)
+// 기본 네임스페이스와 역할은 백엔드의 그룹 매핑 규칙으로 계산되므로 프론트엔드가 토큰을 직접 해석할 필요가 없음
+type UserProfile struct {
+	UserID         string            `json:"user_id"`
+	Subject        string            `json:"sub"`
+	Username       string            `json:"username"`
+	Email          string            `json:"email,omitempty"`
+	Name           string            `json:"name,omitempty"`
+	Groups         []string          `json:"groups"`
+	Role           string            `json:"role"`            // 전체 네임스페이스 중 최고 역할
+	Roles          string            `json:"roles"`           // 콘솔 USER_ROLES와 같은 역할 요약 (예: blue-admin/red-developer)
+	NamespaceRoles map[string]string `json:"namespace_roles"` // 네임스페이스별 최고 역할 (클러스터 관리자는 그룹이 직접 부여한 네임스페이스만 포함, ClusterAdmin으로 판단)
+	ClusterAdmin   bool              `json:"cluster_admin"`
+
+	Namespaces       []string      `json:"namespaces"`        // 역할이 부여된 네임스페이스 (이름순)
+	DefaultNamespace string        `json:"default_namespace"` // 콘솔 실행 시 기본 네임스페이스
+	Clusters         []ClusterInfo `json:"clusters"`          // 사용 가능한 클러스터
+	ActiveConsoles   int           `json:"active_consoles"`   // 실행 중인 웹 콘솔 개수
 }
@@ -34,12 +34,24 @@ func main() {
 	if err != nil {
 		logger.Fatal("Failed to create Kubernetes client", err)
 	}
+	defer k8sClient.Close()
 
-	authHandler, err := handlers.NewAuthHandler(oidcProvider, k8sClient)
+	// 인가 코드 로그인(BFF) 세션 (토큰은 포털 네임스페이스의 Secret에만 저장, 레플리카 간 공유)
+	loginStore, err := kubernetes.NewLoginStore(k8sClient, cfg.JWT.SessionStore, cfg.JWT.SessionNamespace)
+	if err != nil {
+		logger.Fatal("Failed to create login session store", err)
+	}
+	sessionManager := auth.NewSessionManager(oidcProvider, loginStore)
+
+	authHandler, err := handlers.NewAuthHandler(oidcProvider, sessionManager, k8sClient)
 	if err != nil {
 		logger.Fatal("Failed to create auth handler", err)
 	}
-	consoleHandler := handlers.NewConsoleHandler(k8sClient, authHandler)
+	sessionStore, err := kubernetes.NewSessionStore(k8sClient, cfg.Console.SessionStore, cfg.Console.Namespace)
+	if err != nil {
+		logger.Fatal("Failed to create console session store", err)
+	}
+	consoleHandler := handlers.NewConsoleHandler(k8sClient, authHandler, sessionStore)
 
 	gin.SetMode(cfg.Server.GinMode)
 
@@ -69,7 +81,7 @@ func main() {
 			c.Header("Access-Control-Allow-Origin", origin)
 		}
 		c.Header("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, "+middleware.CSRFHeader)
 		c.Header("Access-Control-Allow-Credentials", "true")
 
 		if c.Request.Method == "OPTIONS" {
@@ -80,24 +92,58 @@ func main() {
 		c.Next()
 	})
 
+	// portal-jwt 쿠키만으로 보내는 상태 변경 요청은 X-Requested-With 헤더가 있어야 함
+	r.Use(middleware.CSRFMiddleware())
+
+	// Bearer 액세스 토큰 또는 portal-jwt 쿠키의 세션 토큰을 검증하고 사용자 정보를 컨텍스트에 저장
+	requireAuth := middleware.AuthMiddleware(oidcProvider, sessionManager)
+
+	// 인가 코드 로그인(BFF) 라우트
+	authRoutes := r.Group("/auth")
+	{
+		authRoutes.GET("/login", authHandler.HandleLogin)
+		authRoutes.GET("/callback", authHandler.HandleCallback)
+		authRoutes.POST("/refresh", authHandler.HandleRefresh)
+	}
+
 	// API 라우트 설정
 	api := r.Group("/api")
 	{
 		console := api.Group("/console")
 		{
-			console.GET("/launch", consoleHandler.HandleLaunchConsole)
-			console.GET("/list", consoleHandler.HandleListConsoles)
-			console.DELETE("/:resourceId", consoleHandler.HandleDeleteConsole)
+			// GET 실행은 Bearer 토큰 전용, 쿠키로 인증하는 클라이언트는 POST 사용
+			console.GET("/launch", requireAuth, middleware.RequireBearer(), consoleHandler.HandleLaunchConsole)
+			console.POST("/launch", requireAuth, consoleHandler.HandleLaunchConsole)
+			console.GET("/list", requireAuth, consoleHandler.HandleListConsoles)
+			console.DELETE("/:resourceId", requireAuth, consoleHandler.HandleDeleteConsole)
+			console.GET("/:resourceId/status", requireAuth, consoleHandler.HandleConsoleStatus)
+			console.GET("/:resourceId/events", requireAuth, consoleHandler.HandleConsoleEvents)
+			console.POST("/:resourceId/extend", requireAuth, consoleHandler.HandleExtendConsole)
+
+			// 콘솔 Pod가 세션별 HMAC 토큰으로 호출하므로 사용자 인증 대상이 아님
+			console.POST("/:resourceId/heartbeat", consoleHandler.HandleConsoleHeartbeat)
 		}
 
 		// 하위 호환성을 위한 라우트
-		api.GET("/launch-console", consoleHandler.HandleLaunchConsole)
+		api.GET("/launch-console", requireAuth, middleware.RequireBearer(), consoleHandler.HandleLaunchConsole)
+
+		// 인증된 사용자 프로필 (역할, 네임스페이스, 클러스터, 콘솔 개수)
+		api.GET("/me", requireAuth, consoleHandler.HandleGetMe)
+
+		// 사용 가능한 네임스페이스와 역할 목록
+		api.GET("/namespaces", requireAuth, consoleHandler.HandleListNamespaces)
+
+		// 사용 가능한 클러스터 목록
+		api.GET("/clusters", requireAuth, consoleHandler.HandleListClusters)
+
+		// 로컬 kubectl용 kubeconfig 다운로드
+		api.GET("/kubeconfig", requireAuth, consoleHandler.HandleDownloadKubeconfig)
 
-		// 로그아웃 라우트
+		// 로그아웃 라우트 (portal-jwt 쿠키 사용)
 		api.POST("/logout", consoleHandler.HandleLogout)
 		
 		// 사용자별 모든 리소스 삭제 라우트
-		api.POST("/logout-cleanup", consoleHandler.HandleDeleteUserResources)
+		api.POST("/logout-cleanup", requireAuth, consoleHandler.HandleDeleteUserResources)
 	}
 
 	// 헬스체크 엔드포인트
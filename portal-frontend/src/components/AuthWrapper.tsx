@@ -1,10 +1,8 @@
 import { useState, useEffect } from 'react';
-import { useAuth } from 'react-oidc-context';
 import { LoginPage } from './LoginPage';
 import { Dashboard } from './Dashboard';
-import { Callback } from './Callback';
-import { useLocation } from 'react-router-dom';
 import { AppUser, UserProject, AuthState, mockProjects } from '../types/user';
+import { backendAuthService, UserProfile } from '../services/backendAuthService';
 
 // Keycloak groups에서 프로젝트 정보 파싱 함수
 function parseUserProjectsFromGroups(groups: string[] | undefined): UserProject[] {
@@ -63,10 +61,22 @@ function getRoleLabel(role: string): string {
   return roleLabels[role] || role;
 }
 
+// 백엔드 사용자 프로필(/api/me)을 AppUser 타입으로 변환
+function toAppUser(profile: UserProfile): AppUser {
+  return {
+    sub: profile.sub,
+    preferred_username: profile.username,
+    name: profile.name || 'Unknown User',
+    email: profile.email || '',
+
+    // 앱에서 관리하는 프로젝트 정보 (Keycloak groups 필드에서 파싱)
+    projects: parseUserProjectsFromGroups(profile.groups) || mockProjects
+  };
+}
+
 export function AuthWrapper() {
-  const auth = useAuth();
-  const { signinRedirect, isAuthenticated, user, isLoading, events } = auth;
-  const location = useLocation();
+  const [isLoading, setIsLoading] = useState(true);
+  const [loadError, setLoadError] = useState<string | null>(null);
 
   // 상태 관리
   const [authState, setAuthState] = useState<AuthState>({
@@ -74,116 +84,31 @@ export function AuthWrapper() {
     currentProject: null
   });
 
-  // 토큰 자동 갱신 이벤트 리스너 추가
+  // 백엔드 로그인 세션(portal-jwt 쿠키)으로 사용자 정보 조회
+  // 액세스 토큰 갱신은 백엔드가 요청마다 처리하므로 브라우저는 토큰을 다루지 않음
   useEffect(() => {
-    if (!events) return;
-
-    // 토큰이 만료되기 전 이벤트
-    const handleAccessTokenExpiring = () => {
-      console.log('[AuthWrapper] 🔄 토큰이 곧 만료됩니다. 자동 갱신 시도 중...');
-      if (user?.expires_at) {
-        const expiresAt = new Date(user.expires_at * 1000);
-        console.log('[AuthWrapper] 현재 토큰 만료 시간:', expiresAt.toLocaleString());
-      }
-    };
-
-    // 토큰이 만료된 후 이벤트
-    const handleAccessTokenExpired = () => {
-      console.error('[AuthWrapper] ⚠️ 토큰이 만료되었습니다!');
-      console.log('[AuthWrapper] 자동 갱신 실패. 재로그인이 필요할 수 있습니다.');
-    };
-
-    // 토큰이 자동으로 갱신된 후 이벤트
-    const handleUserLoaded = (user: any) => {
-      console.log('[AuthWrapper] ✅ 토큰이 성공적으로 갱신되었습니다!');
-      if (user?.expires_at) {
-        const expiresAt = new Date(user.expires_at * 1000);
-        console.log('[AuthWrapper] 새 토큰 만료 시간:', expiresAt.toLocaleString());
-        const now = new Date();
-        const timeUntilExpiry = Math.floor((expiresAt.getTime() - now.getTime()) / 1000 / 60);
-        console.log(`[AuthWrapper] ${timeUntilExpiry}분 후에 만료됩니다`);
+    const loadUser = async () => {
+      try {
+        const profile = await backendAuthService.getMe();
+        if (profile) {
+          const appUserData = toAppUser(profile);
+          console.log('App User Data:', appUserData); // 디버깅용
+
+          setAuthState({
+            user: appUserData,
+            currentProject: appUserData.projects[0] || null // 첫 번째 프로젝트를 기본값으로 설정
+          });
+        }
+      } catch (error) {
+        console.error('사용자 정보 조회 실패:', error);
+        setLoadError('사용자 정보를 불러오지 못했습니다. 잠시 후 다시 시도하여 주세요.');
+      } finally {
+        setIsLoading(false);
       }
     };
 
-    // Silent renew 에러
-    const handleSilentRenewError = (error: Error) => {
-      console.error('[AuthWrapper] ❌ Silent renew 실패:', error);
-      console.error('[AuthWrapper] 에러 상세:', error.message);
-      console.error('[AuthWrapper] 확인사항:');
-      console.error('  1. Keycloak에서 /silent-callback이 Valid Redirect URIs에 등록되어 있는지');
-      console.error('  2. 브라우저에서 third-party cookies가 차단되어 있지 않은지');
-      console.error('  3. Keycloak의 Access Token Lifespan 설정 확인');
-    };
-
-    // 사용자 언로드 (로그아웃)
-    const handleUserUnloaded = () => {
-      console.log('[AuthWrapper] 사용자가 로그아웃되었습니다');
-    };
-
-    // 이벤트 리스너 등록
-    events.addAccessTokenExpiring(handleAccessTokenExpiring);
-    events.addAccessTokenExpired(handleAccessTokenExpired);
-    events.addUserLoaded(handleUserLoaded);
-    events.addSilentRenewError(handleSilentRenewError);
-    events.addUserUnloaded(handleUserUnloaded);
-
-    console.log('[AuthWrapper] 토큰 자동 갱신 이벤트 리스너 등록 완료');
-
-    // 클린업
-    return () => {
-      events.removeAccessTokenExpiring(handleAccessTokenExpiring);
-      events.removeAccessTokenExpired(handleAccessTokenExpired);
-      events.removeUserLoaded(handleUserLoaded);
-      events.removeSilentRenewError(handleSilentRenewError);
-      events.removeUserUnloaded(handleUserUnloaded);
-    };
-  }, [events, user]);
-
-  // 사용자 로그인 시 실제 OIDC 토큰 정보와 프로젝트 데이터 결합
-  useEffect(() => {
-    if (isAuthenticated && user && !authState.user) {
-      console.log('OIDC User Info:', user); // 디버깅용
-      console.log('OIDC Profile:', user.profile); // 디버깅용
-      console.log('OIDC Groups from profile:', user.profile?.groups); // 디버깅용
-      console.log('OIDC Groups from user:', (user as any)?.groups); // 디버깅용
-
-      // 토큰 만료 시간 로그
-      if (user.expires_at) {
-        const expiresAt = new Date(user.expires_at * 1000);
-        console.log('[AuthWrapper] 초기 토큰 만료 시간:', expiresAt.toLocaleString());
-        const now = new Date();
-        const timeUntilExpiry = Math.floor((expiresAt.getTime() - now.getTime()) / 1000 / 60);
-        console.log(`[AuthWrapper] ${timeUntilExpiry}분 후에 만료됩니다`);
-      }
-
-      // 실제 OIDC 사용자 정보를 AppUser 타입으로 변환
-      const profile = user.profile || {};
-      const appUserData: AppUser = {
-        // OIDC 기본 정보 - profile 객체에서 가져오기
-        sub: (profile as any)?.sub || (user as any)?.sub || '',
-        preferred_username: (profile as any)?.preferred_username || (user as any)?.preferred_username || '',
-        name: (profile as any)?.name || (user as any)?.name || 'Unknown User',
-        email: (profile as any)?.email || (user as any)?.email || '',
-        given_name: (profile as any)?.given_name || (user as any)?.given_name || '',
-        family_name: (profile as any)?.family_name || (user as any)?.family_name || '',
-        email_verified: (profile as any)?.email_verified || (user as any)?.email_verified || false,
-        auth_time: (profile as any)?.auth_time || (user as any)?.auth_time || 0,
-        access_token: (user as any)?.access_token || '',
-        id_token: (user as any)?.id_token || '',
-        
-        // 앱에서 관리하는 프로젝트 정보
-        // 실제 환경에서는 Keycloak groups 필드에서 파싱하거나 LDAP API 호출
-        projects: parseUserProjectsFromGroups((profile as any)?.groups || (user as any)?.groups) || mockProjects
-      };
-      
-      console.log('App User Data:', appUserData); // 디버깅용
-      
-      setAuthState({
-        user: appUserData,
-        currentProject: appUserData.projects[0] || null // 첫 번째 프로젝트를 기본값으로 설정
-      });
-    }
-  }, [isAuthenticated, user, authState.user]);
+    void loadUser();
+  }, []);
 
   // 프로젝트 변경 핸들러
   const handleProjectChange = (project: UserProject) => {
@@ -193,48 +118,25 @@ export function AuthWrapper() {
     }));
   };
 
-  // 로그아웃 핸들러 (웹 콘솔 리소스 삭제 + 키클락 세션 로그아웃)
+  // 로그아웃 핸들러 (웹 콘솔 리소스 삭제 + 백엔드 세션 삭제 + 키클락 세션 로그아웃)
   const handleLogout = async () => {
     try {
       console.log('로그아웃 시작...');
-      
-      // 1. 백엔드 리소스 정리 API 호출 (웹 콘솔 리소스 삭제)
-      if (authState.user?.access_token) {
-        try {
-          const { backendAuthService } = await import('../services/backendAuthService');
-          await backendAuthService.logout(authState.user.access_token);
-          console.log('웹 콘솔 리소스 정리 완료');
-        } catch (error) {
-          console.error('웹 콘솔 리소스 정리 실패:', error);
-          // 리소스 정리 실패해도 로그아웃은 계속 진행
-        }
-      }
-      
-      // 2. 로컬 스토리지에서 OIDC 사용자 정보 정리
-      const oidcUserKey = 'oidc.user:https://keycloak.miribit.cloud/realms/sso-demo:frontend';
-      localStorage.removeItem(oidcUserKey);
+
+      // 1. 백엔드 로그아웃 API 호출 (웹 콘솔 리소스 삭제, 로그인 세션과 portal-jwt 쿠키 삭제)
+      const logoutUrl = await backendAuthService.logout();
+
+      // 2. 상태 초기화
       sessionStorage.clear();
-      
-      // 3. 상태 초기화
       setAuthState({
         user: null,
         currentProject: null
       });
-      
-      // 4. 키클락 세션 로그아웃 (확인 페이지 없이 바로 로그아웃)
-      const idToken = user?.id_token;
-      let logoutUrl;
-      
-      if (idToken) {
-        // id_token_hint 사용으로 확인 페이지 생략
-        logoutUrl = `https://keycloak.miribit.cloud/realms/sso-demo/protocol/openid-connect/logout?client_id=frontend&post_logout_redirect_uri=${encodeURIComponent('https://portal.miribit.cloud')}&id_token_hint=${idToken}`;
-      } else {
-        logoutUrl = `https://keycloak.miribit.cloud/realms/sso-demo/protocol/openid-connect/logout?client_id=frontend&post_logout_redirect_uri=${encodeURIComponent('https://portal.miribit.cloud')}`;
-      }
-      
+
+      // 3. 키클락 세션 로그아웃 (백엔드가 id_token_hint를 포함한 URL을 반환)
       console.log('키클락 로그아웃 URL로 리다이렉트:', logoutUrl);
-      window.location.href = logoutUrl;
-      
+      window.location.href = logoutUrl || '/';
+
     } catch (error) {
       console.error('로그아웃 중 오류:', error);
       // 오류가 발생해도 강제로 홈으로 리다이렉트
@@ -254,28 +156,20 @@ export function AuthWrapper() {
     );
   }
 
-  // 콜백 페이지인 경우
-  if (location.pathname === '/callback') {
-    return <Callback />;
-  }
-
-  // 인증되지 않은 경우 로그인 페이지 표시
-  if (!isAuthenticated) {
-    return <LoginPage onLogin={() => signinRedirect()} />;
-  }
-
-  // 인증된 경우 대시보드 표시 (사용자 정보가 로드되지 않은 경우 로딩 표시)
-  if (!authState.user) {
+  // 사용자 정보 조회 실패 (백엔드 장애 등)
+  if (loadError) {
     return (
       <div className="min-h-screen flex items-center justify-center">
-        <div className="text-center">
-          <div className="animate-spin rounded-full h-12 w-12 border-b-2 border-primary mx-auto mb-4"></div>
-          <p className="text-muted-foreground">사용자 정보를 불러오는 중...</p>
-        </div>
+        <p className="text-red-500">{loadError}</p>
       </div>
     );
   }
 
+  // 인증되지 않은 경우 로그인 페이지 표시 (로그인은 백엔드 /auth/login에서 진행)
+  if (!authState.user) {
+    return <LoginPage onLogin={() => backendAuthService.login()} />;
+  }
+
   return (
     <Dashboard 
       user={authState.user}
@@ -284,4 +178,4 @@ export function AuthWrapper() {
       onLogout={handleLogout}
     />
   );
-}
\ No newline at end of file
+}
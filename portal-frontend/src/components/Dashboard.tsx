@@ -271,7 +271,7 @@ export function Dashboard({ user, currentProject, onProjectChange, onLogout }: D
                         window.open(menuItems.find(item => item.id === activeMenu)?.url, '_blank');
                       }
                     }}
-                    disabled={activeMenu === 'terminal' && (!user?.access_token || isLoading)}
+                    disabled={activeMenu === 'terminal' && isLoading}
                   >
                     {activeMenu === 'terminal' && isLoading ? (
                       <>
@@ -1,5 +1,4 @@
 import {useEffect, useState} from "react";
-import {useAuth} from "react-oidc-context";
 import {backendAuthService} from "@/services/backendAuthService.ts";
 import {verifyTerminalReady} from "@/services/terminalService.ts";
 import {Spinner} from "@/components/ui/spinner.tsx";
@@ -8,18 +7,21 @@ import {Button} from "@/components/ui/button.tsx";
 
 const TERM_URL_SESSION_KEY = 'TERM_URL_SESSION_KEY';
 
+const PHASE_STATUS_TEXT: Record<string, string> = {
+    'creating': "웹 터미널 관련 리소스를 생성하고 있습니다...",
+    'scheduling': "웹 터미널을 배치할 노드를 찾고 있습니다...",
+    'pulling image': "웹 터미널 이미지를 내려받고 있습니다...",
+    'starting': "웹 터미널을 시작하고 있습니다...",
+    'ready': "웹 터미널에 연결하고 있습니다...",
+};
+
 const Terminal = () => {
-    const { user } = useAuth();
     const [isLoading, setIsLoading] = useState(true);
     const [statusText, setStatusText] = useState<string>("터미널을 준비하고 있습니다...");
     const [errorText, setErrorText] = useState<string| null>(null);
     const [terminalUrl, setTerminalUrl] = useState<string | null>(null);
 
     useEffect(() => {
-        if (!user?.access_token) {
-            return;
-        }
-
         const validateAndSetUrl = async (url: string): Promise<boolean> => {
             const isReady = await verifyTerminalReady(url);
             if (isReady) {
@@ -32,6 +34,13 @@ const Terminal = () => {
 
         const getTerminalUrl = async () => {
             try {
+                // 로그인 세션이 없으면 백엔드 로그인 후 이 페이지로 돌아옴
+                const profile = await backendAuthService.getMe();
+                if (!profile) {
+                    backendAuthService.login('/terminal');
+                    return;
+                }
+
                 // 저장된 URL 확인
                 const savedUrl = sessionStorage.getItem(TERM_URL_SESSION_KEY);
                 if (savedUrl) {
@@ -45,7 +54,20 @@ const Terminal = () => {
 
                 // 새 URL 생성
                 setStatusText("웹 터미널 관련 리소스를 생성하고 있습니다...");
-                const result = await backendAuthService.launchWebConsole(user.access_token);
+                const result = await backendAuthService.launchWebConsole();
+
+                // 프로비저닝 상태가 ready가 될 때까지 대기
+                if (result.status !== 'ready') {
+                    const status = await backendAuthService.waitForConsoleReady(
+                        result.resourceId,
+                        (s) => setStatusText(PHASE_STATUS_TEXT[s.phase] ?? s.phase),
+                    );
+                    if (status.phase === 'failed') {
+                        setErrorText(`웹 터미널 생성 실패: ${status.reason ?? ''} ${status.message ?? ''}`.trim());
+                        return;
+                    }
+                }
+
                 const isValidUrl = await validateAndSetUrl(result.url);
 
                 if (!isValidUrl) {
@@ -59,7 +81,7 @@ const Terminal = () => {
         };
 
         void getTerminalUrl();
-    }, [user]);
+    }, []);
 
     const handleRefresh = () => {
         window.location.reload();
@@ -1,4 +1,32 @@
-// 백엔드 인증 서비스 - OIDC 토큰을 직접 사용하여 백엔드 API 호출
+import { cookieAuthHeaders } from './terminalService';
+
+// 콘솔 프로비저닝 상태 (GET /api/console/:resourceId/status)
+export interface ConsoleStatus {
+  resource_id: string;
+  phase: 'creating' | 'scheduling' | 'pulling image' | 'starting' | 'ready' | 'failed';
+  ready: boolean;
+  reason?: string;
+  message?: string;
+  console_url: string;
+}
+
+// 로그인한 사용자 프로필 (GET /api/me)
+export interface UserProfile {
+  user_id: string;
+  sub: string;
+  username: string;
+  email?: string;
+  name?: string;
+  groups: string[];
+  role: string;
+  roles: string;
+  namespaces: string[];
+  default_namespace: string;
+  active_consoles: number;
+}
+
+// 백엔드 인증 서비스 - 백엔드 로그인 세션(portal-jwt 쿠키)으로 백엔드 API 호출
+// 토큰은 백엔드에만 보관되며 브라우저는 쿠키만 보냄 (상태 변경 요청에는 X-Requested-With 헤더 필요)
 export class BackendAuthService {
   private static instance: BackendAuthService;
 
@@ -10,37 +38,67 @@ export class BackendAuthService {
   }
 
   /**
-   * Web Console 실행 (OIDC Access Token 사용)
-   * @param oidcAccessToken - react-oidc-context에서 받은 access_token
+   * 백엔드 로그인 시작 (/auth/login으로 이동, 로그인 후 redirect 경로로 돌아옴)
+   * @param redirect - 로그인 후 돌아올 프론트엔드 경로 (기본값: 현재 경로)
+   */
+  login(redirect: string = window.location.pathname + window.location.search): void {
+    window.location.href = `/auth/login?redirect=${encodeURIComponent(redirect)}`;
+  }
+
+  /**
+   * 로그인한 사용자 프로필 조회
+   * @returns 로그인 세션이 없으면 null
    */
-  async launchWebConsole(oidcAccessToken: string): Promise<{ url: string; resourceId: string }> {
+  async getMe(): Promise<UserProfile | null> {
+    const response = await fetch('/api/me', {
+      method: 'GET',
+      credentials: 'include',
+      headers: cookieAuthHeaders,
+    });
+
+    if (response.status === 401) {
+      return null;
+    }
+    if (!response.ok) {
+      const errorData = await response.json().catch(() => ({
+        error: { message: response.statusText }
+      }));
+      throw new Error(`Failed to get user profile: ${errorData.error?.message || response.statusText}`);
+    }
+
+    const data = await response.json();
+    return data.data;
+  }
+
+  /**
+   * Web Console 실행 (쿠키 인증은 POST /api/console/launch만 허용됨)
+   */
+  async launchWebConsole(): Promise<{ url: string; resourceId: string; status: string }> {
     try {
-      console.log('Launching web console with OIDC token...');
-      
-      const response = await fetch('/api/launch-console', {
-        method: 'GET',
-        headers: {
-          'Authorization': `Bearer ${oidcAccessToken}`,
-          'Content-Type': 'application/json',
-        }
+      console.log('Launching web console...');
+
+      const response = await fetch('/api/console/launch', {
+        method: 'POST',
+        credentials: 'include',
+        headers: cookieAuthHeaders,
       });
 
       console.log('Launch console response status:', response.status);
 
       if (!response.ok) {
-        const errorData = await response.json().catch(() => ({ 
-          error: { 
+        const errorData = await response.json().catch(() => ({
+          error: {
             message: response.statusText,
             code: 'NETWORK_ERROR',
             type: 'AUTHENTICATION_ERROR'
-          } 
+          }
         }));
         console.error('Web console launch failed:', errorData);
         throw new Error(`Failed to launch web console: ${errorData.error?.message || response.statusText}`);
       }
 
       const data = await response.json();
-      
+
       if (!data.data?.url) {
         throw new Error('Console URL not received from server');
       }
@@ -48,7 +106,8 @@ export class BackendAuthService {
       console.log('Web console launched successfully:', data.data);
       return {
         url: data.data.url,
-        resourceId: data.data.resource_id
+        resourceId: data.data.resource_id,
+        status: data.data.status
       };
     } catch (error) {
       console.error('Error launching web console:', error);
@@ -57,28 +116,78 @@ export class BackendAuthService {
   }
 
   /**
-   * 콘솔 목록 조회 (OIDC Access Token 사용)
-   * @param oidcAccessToken - react-oidc-context에서 받은 access_token
+   * 콘솔 프로비저닝 상태 조회
+   * @param resourceId - 조회할 리소스 ID
    */
-  async listConsoles(oidcAccessToken: string): Promise<any[]> {
+  async getConsoleStatus(resourceId: string): Promise<ConsoleStatus> {
+    const response = await fetch(`/api/console/${resourceId}/status`, {
+      method: 'GET',
+      credentials: 'include',
+      headers: cookieAuthHeaders,
+    });
+
+    if (!response.ok) {
+      const errorData = await response.json().catch(() => ({
+        error: { message: response.statusText }
+      }));
+      throw new Error(`Failed to get console status: ${errorData.error?.message || response.statusText}`);
+    }
+
+    const data = await response.json();
+    return data.data;
+  }
+
+  /**
+   * 콘솔이 ready 또는 failed 상태가 될 때까지 상태 API를 폴링
+   * @param resourceId - 대기할 리소스 ID
+   * @param onPhase - 단계가 바뀔 때마다 호출되는 콜백
+   * @param timeoutMs - 최대 대기 시간 (기본값: 백엔드 프로비저닝 제한 2분 + 여유 30초, 제한이 지나면 백엔드가 failed를 보고함)
+   * @param intervalMs - 폴링 간격 (기본값: 2초)
+   */
+  async waitForConsoleReady(
+    resourceId: string,
+    onPhase?: (status: ConsoleStatus) => void,
+    timeoutMs: number = 150000,
+    intervalMs: number = 2000
+  ): Promise<ConsoleStatus> {
+    const deadline = Date.now() + timeoutMs;
+    let lastPhase = '';
+
+    while (Date.now() < deadline) {
+      const status = await this.getConsoleStatus(resourceId);
+      if (status.phase !== lastPhase) {
+        lastPhase = status.phase;
+        onPhase?.(status);
+      }
+      if (status.ready || status.phase === 'failed') {
+        return status;
+      }
+      await new Promise(resolve => setTimeout(resolve, intervalMs));
+    }
+
+    throw new Error(`Console ${resourceId} not ready after ${timeoutMs}ms (last phase: ${lastPhase})`);
+  }
+
+  /**
+   * 콘솔 목록 조회
+   */
+  async listConsoles(): Promise<any[]> {
     try {
       const response = await fetch('/api/console/list', {
         method: 'GET',
-        headers: {
-          'Authorization': `Bearer ${oidcAccessToken}`,
-          'Content-Type': 'application/json',
-        }
+        credentials: 'include',
+        headers: cookieAuthHeaders,
       });
 
       if (!response.ok) {
-        const errorData = await response.json().catch(() => ({ 
-          error: { message: response.statusText } 
+        const errorData = await response.json().catch(() => ({
+          error: { message: response.statusText }
         }));
         throw new Error(`Failed to list consoles: ${errorData.error?.message || response.statusText}`);
       }
 
       const data = await response.json();
-      return data.consoles || [];
+      return data.data?.consoles || [];
     } catch (error) {
       console.error('Error listing consoles:', error);
       throw error;
@@ -86,23 +195,20 @@ export class BackendAuthService {
   }
 
   /**
-   * 콘솔 삭제 (OIDC Access Token 사용)
-   * @param oidcAccessToken - react-oidc-context에서 받은 access_token
+   * 콘솔 삭제
    * @param resourceId - 삭제할 리소스 ID
    */
-  async deleteConsole(oidcAccessToken: string, resourceId: string): Promise<void> {
+  async deleteConsole(resourceId: string): Promise<void> {
     try {
       const response = await fetch(`/api/console/${resourceId}`, {
         method: 'DELETE',
-        headers: {
-          'Authorization': `Bearer ${oidcAccessToken}`,
-          'Content-Type': 'application/json',
-        }
+        credentials: 'include',
+        headers: cookieAuthHeaders,
       });
 
       if (!response.ok) {
-        const errorData = await response.json().catch(() => ({ 
-          error: { message: response.statusText } 
+        const errorData = await response.json().catch(() => ({
+          error: { message: response.statusText }
         }));
         throw new Error(`Failed to delete console: ${errorData.error?.message || response.statusText}`);
       }
@@ -113,43 +219,34 @@ export class BackendAuthService {
   }
 
   /**
-   * 리소스 정리 (OIDC Access Token 사용)
-   * @param oidcAccessToken - react-oidc-context에서 받은 access_token
+   * 로그아웃 (웹 콘솔 리소스 정리 + 백엔드 로그인 세션 삭제 + portal-jwt 쿠키 삭제)
+   * @returns IdP 세션 로그아웃 URL
    */
-  async logout(oidcAccessToken: string): Promise<void> {
+  async logout(): Promise<string | undefined> {
     try {
-      console.log('Calling logout cleanup API...');
-      
-      const response = await fetch('/api/logout-cleanup', {
+      console.log('Calling logout API...');
+
+      const response = await fetch('/api/logout', {
         method: 'POST',
-        headers: {
-          'Authorization': `Bearer ${oidcAccessToken}`,
-          'Content-Type': 'application/json',
-        }
+        credentials: 'include',
+        headers: cookieAuthHeaders,
       });
 
       if (!response.ok) {
-        const errorData = await response.json().catch(() => ({ 
-          error: { message: response.statusText } 
+        const errorData = await response.json().catch(() => ({
+          error: { message: response.statusText }
         }));
-        throw new Error(`Failed to cleanup resources: ${errorData.error?.message || response.statusText}`);
+        throw new Error(`Failed to logout: ${errorData.error?.message || response.statusText}`);
       }
 
       const data = await response.json();
-      console.log('Resource cleanup successful:', data);
-      
+      console.log('Logout successful:', data);
+      return data.logout_url;
     } catch (error) {
-      console.error('Error during resource cleanup:', error);
+      console.error('Error during logout:', error);
       throw error;
     }
   }
-
-  /**
-   * 세션 상태 리셋 (호환성을 위해 유지하지만 아무 작업 안 함)
-   */
-  resetSessionState(): void {
-    // 더 이상 세션을 관리하지 않으므로 아무 작업 안 함
-  }
 }
 
 export const backendAuthService = BackendAuthService.getInstance();
@@ -16,6 +16,15 @@ export interface TerminalListResponse {
   userId: string;
 }
 
+/**
+ * portal-jwt 쿠키로 인증하는 요청의 공통 헤더
+ * 백엔드는 쿠키 인증 상태 변경 요청에 X-Requested-With 헤더를 요구함 (CSRF 방어)
+ */
+export const cookieAuthHeaders = {
+  'Content-Type': 'application/json',
+  'X-Requested-With': 'XMLHttpRequest',
+};
+
 export interface ApiResponse<T> {
   success: boolean;
   data?: T;
@@ -80,12 +89,11 @@ export async function verifyTerminalReady(
  * @returns Promise<TerminalLaunchResponse>
  */
 export async function launchTerminal(): Promise<TerminalLaunchResponse> {
-  const response = await fetch('/api/launch-console', {
-    method: 'GET',
+  // 쿠키(portal-jwt)로 인증하는 실행 요청은 POST만 허용됨
+  const response = await fetch('/api/console/launch', {
+    method: 'POST',
     credentials: 'include',
-    headers: {
-      'Content-Type': 'application/json',
-    },
+    headers: cookieAuthHeaders,
   });
 
   if (!response.ok) {
@@ -127,9 +135,7 @@ export async function listTerminals(): Promise<TerminalListResponse> {
   const response = await fetch('/api/console/list', {
     method: 'GET',
     credentials: 'include',
-    headers: {
-      'Content-Type': 'application/json',
-    },
+    headers: cookieAuthHeaders,
   });
 
   if (!response.ok) {
@@ -150,9 +156,7 @@ export async function deleteTerminal(resourceId: string): Promise<void> {
   const response = await fetch(`/api/console/${resourceId}`, {
     method: 'DELETE',
     credentials: 'include',
-    headers: {
-      'Content-Type': 'application/json',
-    },
+    headers: cookieAuthHeaders,
   });
 
   if (!response.ok) {
@@ -170,9 +174,7 @@ export async function checkAuthStatus(): Promise<boolean> {
     const response = await fetch('/api/user', {
       method: 'GET',
       credentials: 'include',
-      headers: {
-        'Content-Type': 'application/json',
-      },
+      headers: cookieAuthHeaders,
     });
 
     return response.ok;
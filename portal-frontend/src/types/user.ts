@@ -7,7 +7,7 @@ export interface UserProject {
   roleLabel: string; // 권한 표시명 (예: '개발자', '관리자')
 }
 
-// 실제 OIDC 사용자 정보를 확장한 타입
+// 백엔드 사용자 프로필(/api/me)을 확장한 타입 (토큰은 백엔드 로그인 세션에만 보관)
 export interface AppUser {
   // OIDC 기본 정보
   sub: string;
@@ -16,10 +16,6 @@ export interface AppUser {
   email?: string;
   given_name?: string;
   family_name?: string;
-  email_verified?: boolean;
-  auth_time?: number;
-  access_token?: string;
-  id_token?: string;
   
   // 앱에서 추가로 관리하는 정보
   projects: UserProject[]; // 소속 프로젝트 목록
@@ -65,6 +65,11 @@
           secure: true,
           rewrite: (path) => path,
         },
+        '/auth': {
+          target: 'https://portal.miribit.cloud',
+          changeOrigin: true,
+          secure: true,
+        },
       },
     },
   });
\ No newline at end of file